@@ -0,0 +1,19 @@
+package spreadsheet
+
+// BandedRange is a banded (alternating colors) range in a sheet.
+// At least one of RowProperties and ColumnProperties should be set.
+type BandedRange struct {
+	BandedRangeID    uint               `json:"bandedRangeId,omitempty"`
+	Range            GridRange          `json:"range"`
+	RowProperties    *BandingProperties `json:"rowProperties,omitempty"`
+	ColumnProperties *BandingProperties `json:"columnProperties,omitempty"`
+}
+
+// BandingProperties is properties referring a single dimension (either row or column).
+// The first band color and the second band color alternate, starting after the header (if any).
+type BandingProperties struct {
+	HeaderColor     *Color `json:"headerColor,omitempty"`
+	FirstBandColor  *Color `json:"firstBandColor,omitempty"`
+	SecondBandColor *Color `json:"secondBandColor,omitempty"`
+	FooterColor     *Color `json:"footerColor,omitempty"`
+}
@@ -0,0 +1,34 @@
+package spreadsheet
+
+// BasicFilter is the default filter associated with a sheet.
+type BasicFilter struct {
+	Range     GridRange  `json:"range"`
+	SortSpecs []SortSpec `json:"sortSpecs,omitempty"`
+	// Criteria is the criteria for showing/hiding values per column.
+	// The map's key is the column index, and the value is the criteria for that column.
+	Criteria map[string]FilterCriteria `json:"criteria,omitempty"`
+}
+
+// FilterCriteria is the criteria for showing/hiding rows in a filter or filter view.
+type FilterCriteria struct {
+	HiddenValues           []string          `json:"hiddenValues,omitempty"`
+	Condition              *BooleanCondition `json:"condition,omitempty"`
+	VisibleBackgroundColor *Color            `json:"visibleBackgroundColor,omitempty"`
+	VisibleForegroundColor *Color            `json:"visibleForegroundColor,omitempty"`
+}
+
+// SortOrder is the order of sorting.
+type SortOrder string
+
+const (
+	// SortOrderAscending sorts ascending.
+	SortOrderAscending SortOrder = "ASCENDING"
+	// SortOrderDescending sorts descending.
+	SortOrderDescending SortOrder = "DESCENDING"
+)
+
+// SortSpec is a sort order associated with a specific column or row.
+type SortSpec struct {
+	DimensionIndex uint      `json:"dimensionIndex"`
+	SortOrder      SortOrder `json:"sortOrder"`
+}
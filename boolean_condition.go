@@ -0,0 +1,160 @@
+package spreadsheet
+
+import (
+	"net/mail"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ConditionType is the type of a condition.
+type ConditionType string
+
+// The types of conditions.
+// Each type requires a particular number of condition values.
+const (
+	ConditionNumberGreater    ConditionType = "NUMBER_GREATER"
+	ConditionNumberGreaterEq  ConditionType = "NUMBER_GREATER_THAN_EQ"
+	ConditionNumberLess       ConditionType = "NUMBER_LESS"
+	ConditionNumberLessEq     ConditionType = "NUMBER_LESS_THAN_EQ"
+	ConditionNumberEq         ConditionType = "NUMBER_EQ"
+	ConditionNumberNotEq      ConditionType = "NUMBER_NOT_EQ"
+	ConditionNumberBetween    ConditionType = "NUMBER_BETWEEN"
+	ConditionNumberNotBetween ConditionType = "NUMBER_NOT_BETWEEN"
+	ConditionTextContains     ConditionType = "TEXT_CONTAINS"
+	ConditionTextNotContains  ConditionType = "TEXT_NOT_CONTAINS"
+	ConditionTextStartsWith   ConditionType = "TEXT_STARTS_WITH"
+	ConditionTextEndsWith     ConditionType = "TEXT_ENDS_WITH"
+	ConditionTextEq           ConditionType = "TEXT_EQ"
+	ConditionTextNotEq        ConditionType = "TEXT_NOT_EQ"
+	ConditionTextIsEmail      ConditionType = "TEXT_IS_EMAIL"
+	ConditionTextIsURL        ConditionType = "TEXT_IS_URL"
+	ConditionDateEq           ConditionType = "DATE_EQ"
+	ConditionDateBefore       ConditionType = "DATE_BEFORE"
+	ConditionDateAfter        ConditionType = "DATE_AFTER"
+	ConditionDateOnOrBefore   ConditionType = "DATE_ON_OR_BEFORE"
+	ConditionDateOnOrAfter    ConditionType = "DATE_ON_OR_AFTER"
+	ConditionDateBetween      ConditionType = "DATE_BETWEEN"
+	ConditionDateNotBetween   ConditionType = "DATE_NOT_BETWEEN"
+	ConditionDateIsValid      ConditionType = "DATE_IS_VALID"
+	ConditionOneOfRange       ConditionType = "ONE_OF_RANGE"
+	ConditionOneOfList        ConditionType = "ONE_OF_LIST"
+	ConditionBlank            ConditionType = "BLANK"
+	ConditionNotBlank         ConditionType = "NOT_BLANK"
+	ConditionCustomFormula    ConditionType = "CUSTOM_FORMULA"
+	ConditionBoolean          ConditionType = "BOOLEAN"
+	ConditionFilterExpression ConditionType = "FILTER_EXPRESSION"
+)
+
+// BooleanCondition is a condition that can evaluate to true or false.
+type BooleanCondition struct {
+	Type   ConditionType    `json:"type"`
+	Values []ConditionValue `json:"values,omitempty"`
+}
+
+// ConditionValue is the value of a condition.
+// Exactly one of RelativeDate and UserEnteredValue should be set.
+type ConditionValue struct {
+	// RelativeDate is one of PAST_YEAR, PAST_MONTH, PAST_WEEK, YESTERDAY, TODAY and TOMORROW.
+	// It is supported only by DATE_BEFORE, DATE_AFTER, DATE_ON_OR_BEFORE and DATE_ON_OR_AFTER.
+	RelativeDate     string `json:"relativeDate,omitempty"`
+	UserEnteredValue string `json:"userEnteredValue,omitempty"`
+}
+
+// evaluate evaluates the condition against the value as entered by a user.
+// The supported is false if the condition cannot be evaluated locally,
+// e.g. it depends on other cells or formulas.
+func (condition BooleanCondition) evaluate(value string) (ok, supported bool) {
+	values := make([]string, len(condition.Values))
+	for i, v := range condition.Values {
+		if v.RelativeDate != "" || strings.HasPrefix(v.UserEnteredValue, "=") {
+			return false, false
+		}
+		values[i] = v.UserEnteredValue
+	}
+	switch condition.Type {
+	case ConditionBlank:
+		return value == "", true
+	case ConditionNotBlank:
+		return value != "", true
+	case ConditionOneOfList:
+		for _, v := range values {
+			if v == value {
+				return true, true
+			}
+		}
+		return false, true
+	case ConditionBoolean:
+		if len(values) == 0 {
+			return value == "TRUE" || value == "FALSE", true
+		}
+		for _, v := range values {
+			if v == value {
+				return true, true
+			}
+		}
+		return false, true
+	case ConditionTextContains:
+		return len(values) == 1 && strings.Contains(value, values[0]), true
+	case ConditionTextNotContains:
+		return len(values) == 1 && !strings.Contains(value, values[0]), true
+	case ConditionTextStartsWith:
+		return len(values) == 1 && strings.HasPrefix(value, values[0]), true
+	case ConditionTextEndsWith:
+		return len(values) == 1 && strings.HasSuffix(value, values[0]), true
+	case ConditionTextEq:
+		return len(values) == 1 && value == values[0], true
+	case ConditionTextNotEq:
+		return len(values) == 1 && value != values[0], true
+	case ConditionTextIsEmail:
+		_, err := mail.ParseAddress(value)
+		return err == nil, true
+	case ConditionTextIsURL:
+		u, err := url.Parse(value)
+		return err == nil && u.Scheme != "" && u.Host != "", true
+	case ConditionNumberGreater, ConditionNumberGreaterEq, ConditionNumberLess, ConditionNumberLessEq,
+		ConditionNumberEq, ConditionNumberNotEq, ConditionNumberBetween, ConditionNumberNotBetween:
+		return evaluateNumberCondition(condition.Type, value, values), true
+	}
+	return false, false
+}
+
+func evaluateNumberCondition(conditionType ConditionType, value string, values []string) bool {
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || !isNumericFloat(number) {
+		return false
+	}
+	operands := make([]float64, len(values))
+	for i, v := range values {
+		operands[i], err = strconv.ParseFloat(v, 64)
+		if err != nil {
+			return false
+		}
+	}
+	switch conditionType {
+	case ConditionNumberBetween, ConditionNumberNotBetween:
+		if len(operands) != 2 {
+			return false
+		}
+		between := operands[0] <= number && number <= operands[1]
+		return between == (conditionType == ConditionNumberBetween)
+	}
+	if len(operands) != 1 {
+		return false
+	}
+	switch conditionType {
+	case ConditionNumberGreater:
+		return number > operands[0]
+	case ConditionNumberGreaterEq:
+		return number >= operands[0]
+	case ConditionNumberLess:
+		return number < operands[0]
+	case ConditionNumberLessEq:
+		return number <= operands[0]
+	case ConditionNumberEq:
+		return number == operands[0]
+	case ConditionNumberNotEq:
+		return number != operands[0]
+	}
+	return false
+}
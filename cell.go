@@ -1,6 +1,11 @@
 package spreadsheet
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
 
 // Cell describes a cell data
 type Cell struct {
@@ -10,6 +15,12 @@ type Cell struct {
 	Note           string
 	rawValue       ExtendedValue
 	effectiveValue ExtendedValue
+	hyperlink      string
+	textFormatRuns []TextFormatRun
+	dataValidation *DataValidationRule
+	pivotTable     *PivotTable
+	format         *CellFormat
+	sheet          *Sheet
 
 	modifiedFields string
 }
@@ -30,3 +41,126 @@ func (cell *Cell) RawValue() ExtendedValue {
 func (cell *Cell) EffectiveValue() ExtendedValue {
 	return cell.effectiveValue
 }
+
+// Hyperlink returns the hyperlink a cell points to, if any.
+// Links within the text format runs are not included.
+func (cell *Cell) Hyperlink() string {
+	return cell.hyperlink
+}
+
+// TextFormatRuns returns the runs of rich text applied to subsections of a cell.
+func (cell *Cell) TextFormatRuns() []TextFormatRun {
+	return cell.textFormatRuns
+}
+
+// DataValidation returns the data validation rule of a cell, or nil if the cell has no rule.
+func (cell *Cell) DataValidation() *DataValidationRule {
+	return cell.dataValidation
+}
+
+// PivotTable returns the pivot table anchored at a cell, or nil if there is no pivot table.
+func (cell *Cell) PivotTable() *PivotTable {
+	return cell.pivotTable
+}
+
+// Float returns the effective value of a cell if it is a number.
+func (cell *Cell) Float() (float64, bool) {
+	if cell.effectiveValue.Kind() != ValueKindNumber {
+		return 0, false
+	}
+	return cell.effectiveValue.NumberValue, true
+}
+
+// Int returns the effective value of a cell if it is a whole number.
+func (cell *Cell) Int() (int, bool) {
+	f, ok := cell.Float()
+	if !ok || f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, false
+	}
+	return int(f), true
+}
+
+// Bool returns the effective value of a cell if it is a boolean.
+func (cell *Cell) Bool() (bool, bool) {
+	if cell.effectiveValue.Kind() != ValueKindBool {
+		return false, false
+	}
+	return cell.effectiveValue.BoolValue, true
+}
+
+// String returns the effective value of a cell if it is a string.
+func (cell *Cell) String() (string, bool) {
+	if cell.effectiveValue.Kind() != ValueKindString {
+		return "", false
+	}
+	return cell.effectiveValue.StringValue, true
+}
+
+// Formula returns the formula of a cell as entered by a user, if any.
+func (cell *Cell) Formula() (string, bool) {
+	if cell.rawValue.Kind() != ValueKindFormula {
+		return "", false
+	}
+	return cell.rawValue.FormulaValue, true
+}
+
+// Err returns the error of a cell such as #DIV/0!, or nil if the cell has no error.
+func (cell *Cell) Err() error {
+	if cell.effectiveValue.Kind() != ValueKindError {
+		return nil
+	}
+	return cell.effectiveValue.ErrorValue
+}
+
+// UserEnteredFormat returns the format of a cell as entered by a user, or nil if it has no format.
+func (cell *Cell) UserEnteredFormat() *CellFormat {
+	return cell.format
+}
+
+// Time converts the serial number of a date and time in a cell to the time
+// in the time zone of the spreadsheet the cell belongs to.
+// A cell which belongs to no spreadsheet is converted in UTC.
+func (cell *Cell) Time() (t time.Time, err error) {
+	loc := time.UTC
+	if cell.sheet != nil && cell.sheet.Spreadsheet != nil {
+		loc, err = cell.sheet.Spreadsheet.Location()
+		if err != nil {
+			return
+		}
+	}
+	return cell.TimeIn(loc)
+}
+
+// TimeIn converts the serial number of a date and time in a cell to the time in the location.
+func (cell *Cell) TimeIn(loc *time.Location) (t time.Time, err error) {
+	serial, err := cell.serial()
+	if err != nil {
+		return
+	}
+	t = SerialToTime(serial, loc)
+	return
+}
+
+// Duration converts the serial number of a duration in a cell to the duration.
+func (cell *Cell) Duration() (d time.Duration, err error) {
+	serial, err := cell.serial()
+	if err != nil {
+		return
+	}
+	d = SerialToDuration(serial)
+	return
+}
+
+func (cell *Cell) serial() (serial float64, err error) {
+	switch cell.effectiveValue.Kind() {
+	case ValueKindNone:
+		err = errors.New("cell has no value")
+	case ValueKindNumber:
+		serial = cell.effectiveValue.NumberValue
+	case ValueKindError:
+		err = cell.effectiveValue.ErrorValue
+	default:
+		err = errors.New("cell value is not a number")
+	}
+	return
+}
@@ -0,0 +1,52 @@
+package spreadsheet
+
+// CellFormat is the format of a cell.
+type CellFormat struct {
+	NumberFormat    *NumberFormat `json:"numberFormat,omitempty"`
+	BackgroundColor *Color        `json:"backgroundColor,omitempty"`
+	Borders         *Borders      `json:"borders,omitempty"`
+	Padding         *Padding      `json:"padding,omitempty"`
+	// HorizontalAlignment is one of LEFT, CENTER and RIGHT.
+	HorizontalAlignment string `json:"horizontalAlignment,omitempty"`
+	// VerticalAlignment is one of TOP, MIDDLE and BOTTOM.
+	VerticalAlignment string `json:"verticalAlignment,omitempty"`
+	// WrapStrategy is one of OVERFLOW_CELL, LEGACY_WRAP, CLIP and WRAP.
+	WrapStrategy string `json:"wrapStrategy,omitempty"`
+	// TextDirection is LEFT_TO_RIGHT or RIGHT_TO_LEFT.
+	TextDirection string      `json:"textDirection,omitempty"`
+	TextFormat    *TextFormat `json:"textFormat,omitempty"`
+	// HyperlinkDisplayType is LINKED or PLAIN_TEXT.
+	HyperlinkDisplayType string        `json:"hyperlinkDisplayType,omitempty"`
+	TextRotation         *TextRotation `json:"textRotation,omitempty"`
+}
+
+// Borders is the borders of a cell.
+type Borders struct {
+	Top    *Border `json:"top,omitempty"`
+	Bottom *Border `json:"bottom,omitempty"`
+	Left   *Border `json:"left,omitempty"`
+	Right  *Border `json:"right,omitempty"`
+}
+
+// Border is a border along a cell.
+type Border struct {
+	// Style is one of DOTTED, DASHED, SOLID, SOLID_MEDIUM, SOLID_THICK, NONE and DOUBLE.
+	Style string `json:"style"`
+	Color *Color `json:"color,omitempty"`
+}
+
+// Padding is the amount of padding around a cell, in pixels.
+type Padding struct {
+	Top    int `json:"top,omitempty"`
+	Right  int `json:"right,omitempty"`
+	Bottom int `json:"bottom,omitempty"`
+	Left   int `json:"left,omitempty"`
+}
+
+// TextRotation is the rotation applied to text in a cell.
+// Either Angle or Vertical is used.
+type TextRotation struct {
+	// Angle is between -90 and 90 degrees.
+	Angle    int  `json:"angle,omitempty"`
+	Vertical bool `json:"vertical,omitempty"`
+}
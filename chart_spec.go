@@ -0,0 +1,92 @@
+package spreadsheet
+
+// ChartSpec is the specifications of a chart.
+// Exactly one of BasicChart and PieChart should be set.
+type ChartSpec struct {
+	Title           string          `json:"title,omitempty"`
+	Subtitle        string          `json:"subtitle,omitempty"`
+	FontName        string          `json:"fontName,omitempty"`
+	BackgroundColor *Color          `json:"backgroundColor,omitempty"`
+	BasicChart      *BasicChartSpec `json:"basicChart,omitempty"`
+	PieChart        *PieChartSpec   `json:"pieChart,omitempty"`
+}
+
+// BasicChartType is how a basic chart should be visualized.
+type BasicChartType string
+
+const (
+	// BasicChartBar is a bar chart.
+	BasicChartBar BasicChartType = "BAR"
+	// BasicChartLine is a line chart.
+	BasicChartLine BasicChartType = "LINE"
+	// BasicChartArea is an area chart.
+	BasicChartArea BasicChartType = "AREA"
+	// BasicChartColumn is a column chart.
+	BasicChartColumn BasicChartType = "COLUMN"
+	// BasicChartScatter is a scatter chart.
+	BasicChartScatter BasicChartType = "SCATTER"
+	// BasicChartCombo is a combo chart. The type of each series is set by BasicChartSeries.Type.
+	BasicChartCombo BasicChartType = "COMBO"
+	// BasicChartSteppedArea is a stepped area chart.
+	BasicChartSteppedArea BasicChartType = "STEPPED_AREA"
+)
+
+// BasicChartSpec is the specification for a basic chart.
+type BasicChartSpec struct {
+	ChartType BasicChartType `json:"chartType"`
+	// LegendPosition is one of BOTTOM_LEGEND, LEFT_LEGEND, RIGHT_LEGEND, TOP_LEGEND and NO_LEGEND.
+	LegendPosition string             `json:"legendPosition,omitempty"`
+	Axis           []BasicChartAxis   `json:"axis,omitempty"`
+	Domains        []BasicChartDomain `json:"domains,omitempty"`
+	Series         []BasicChartSeries `json:"series,omitempty"`
+	// HeaderCount is the number of rows or columns in the data that are headers.
+	HeaderCount      int  `json:"headerCount,omitempty"`
+	ThreeDimensional bool `json:"threeDimensional,omitempty"`
+	// StackedType is one of NOT_STACKED, STACKED and PERCENT_STACKED.
+	StackedType   string `json:"stackedType,omitempty"`
+	LineSmoothing bool   `json:"lineSmoothing,omitempty"`
+}
+
+// BasicChartAxis is an axis of a basic chart.
+type BasicChartAxis struct {
+	// Position is one of BOTTOM_AXIS, LEFT_AXIS and RIGHT_AXIS.
+	Position string `json:"position"`
+	Title    string `json:"title,omitempty"`
+}
+
+// BasicChartDomain is the domain of a basic chart, e.g. the dates of a chart of stock prices.
+type BasicChartDomain struct {
+	Domain   ChartData `json:"domain"`
+	Reversed bool      `json:"reversed,omitempty"`
+}
+
+// BasicChartSeries is a single series of data in a basic chart.
+type BasicChartSeries struct {
+	Series ChartData `json:"series"`
+	// TargetAxis is one of BOTTOM_AXIS, LEFT_AXIS and RIGHT_AXIS.
+	TargetAxis string `json:"targetAxis,omitempty"`
+	// Type is the type of this series. It is valid only for combo charts.
+	Type BasicChartType `json:"type,omitempty"`
+}
+
+// PieChartSpec is the specification for a pie chart.
+type PieChartSpec struct {
+	// LegendPosition is one of BOTTOM_LEGEND, LEFT_LEGEND, RIGHT_LEGEND, TOP_LEGEND, NO_LEGEND and LABELED_LEGEND.
+	LegendPosition   string    `json:"legendPosition,omitempty"`
+	Domain           ChartData `json:"domain"`
+	Series           ChartData `json:"series"`
+	ThreeDimensional bool      `json:"threeDimensional,omitempty"`
+	// PieHole is the size of the hole in the pie chart, between 0 and 1.
+	PieHole float64 `json:"pieHole,omitempty"`
+}
+
+// ChartData is the data included in a domain or series.
+type ChartData struct {
+	SourceRange ChartSourceRange `json:"sourceRange"`
+}
+
+// ChartSourceRange is the source ranges for a chart.
+// Each range must be a single row or column, and all ranges of a domain and its series must be parallel.
+type ChartSourceRange struct {
+	Sources []GridRange `json:"sources"`
+}
@@ -0,0 +1,10 @@
+package spreadsheet
+
+// Color represents a color in the RGBA color space.
+// Each component is in the interval [0, 1].
+type Color struct {
+	Red   float32 `json:"red,omitempty"`
+	Green float32 `json:"green,omitempty"`
+	Blue  float32 `json:"blue,omitempty"`
+	Alpha float32 `json:"alpha,omitempty"`
+}
@@ -0,0 +1,47 @@
+package spreadsheet
+
+// ConditionalFormatRule is a rule describing a conditional format.
+// Exactly one of BooleanRule and GradientRule should be set.
+type ConditionalFormatRule struct {
+	Ranges       []GridRange   `json:"ranges"`
+	BooleanRule  *BooleanRule  `json:"booleanRule,omitempty"`
+	GradientRule *GradientRule `json:"gradientRule,omitempty"`
+}
+
+// BooleanRule is a rule that may or may not match, depending on the condition.
+type BooleanRule struct {
+	Condition BooleanCondition `json:"condition"`
+	// Format is applied to the cells if the condition is true.
+	// Only bold, italic, strikethrough, foreground color and background color can be set.
+	Format CellFormat `json:"format"`
+}
+
+// GradientRule is a rule that applies a gradient color scale format, based on the interpolation points listed.
+type GradientRule struct {
+	Minpoint InterpolationPoint  `json:"minpoint"`
+	Midpoint *InterpolationPoint `json:"midpoint,omitempty"`
+	Maxpoint InterpolationPoint  `json:"maxpoint"`
+}
+
+// InterpolationPointType is how an interpolation point is calculated.
+type InterpolationPointType string
+
+const (
+	// InterpolationPointMin uses the minimum value in the cell range.
+	InterpolationPointMin InterpolationPointType = "MIN"
+	// InterpolationPointMax uses the maximum value in the cell range.
+	InterpolationPointMax InterpolationPointType = "MAX"
+	// InterpolationPointNumber uses exactly the value.
+	InterpolationPointNumber InterpolationPointType = "NUMBER"
+	// InterpolationPointPercent uses the value as a percentage of the cell range.
+	InterpolationPointPercent InterpolationPointType = "PERCENT"
+	// InterpolationPointPercentile uses the value as a percentile of the cell range.
+	InterpolationPointPercentile InterpolationPointType = "PERCENTILE"
+)
+
+// InterpolationPoint is a single interpolation point on a gradient conditional format.
+type InterpolationPoint struct {
+	Color Color                  `json:"color"`
+	Type  InterpolationPointType `json:"type"`
+	Value string                 `json:"value,omitempty"`
+}
@@ -0,0 +1,128 @@
+package spreadsheet
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// CSVValue is which value of cells is written to CSV.
+type CSVValue string
+
+// Values of cells written to CSV.
+const (
+	// CSVFormattedValue is the value as displayed in the sheet.
+	CSVFormattedValue CSVValue = ""
+	// CSVRawValue is the value as entered by a user, such as a formula.
+	CSVRawValue CSVValue = "RAW"
+	// CSVEffectiveValue is the calculated value without formatting.
+	CSVEffectiveValue CSVValue = "EFFECTIVE"
+)
+
+// CSVOptions is the options to read and write CSV.
+type CSVOptions struct {
+	Value CSVValue
+	// Delimiter is the field delimiter, or a comma if it is zero.
+	Delimiter rune
+	// Trim removes empty trailing rows and columns when writing.
+	Trim bool
+}
+
+func (opts CSVOptions) delimiter() rune {
+	if opts.Delimiter == 0 {
+		return ','
+	}
+	return opts.Delimiter
+}
+
+func (opts CSVOptions) text(cell *Cell) string {
+	switch opts.Value {
+	case CSVRawValue:
+		return cell.rawValue.text()
+	case CSVEffectiveValue:
+		return cell.effectiveValue.text()
+	}
+	return cell.Value
+}
+
+// WriteCSV writes the cells of the sheet to w as CSV.
+func (sheet *Sheet) WriteCSV(w io.Writer, opts CSVOptions) error {
+	var width int
+	for _, row := range sheet.Rows {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+	records := make([][]string, 0, len(sheet.Rows))
+	var rows, columns int
+	for _, row := range sheet.Rows {
+		record := make([]string, width)
+		for i := range row {
+			record[i] = opts.text(&row[i])
+			if record[i] != "" {
+				rows = len(records) + 1
+				if i+1 > columns {
+					columns = i + 1
+				}
+			}
+		}
+		records = append(records, record)
+	}
+	if opts.Trim {
+		records = records[:rows]
+		for i := range records {
+			records[i] = records[i][:columns]
+		}
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Comma = opts.delimiter()
+	return writer.WriteAll(records)
+}
+
+// ReadCSV reads CSV from r and updates the cells from the start of the range.
+// The CSV must fit in the range unless its end indexes are zero.
+// The updates are staged through Update and synchronized in one request.
+func (sheet *Sheet) ReadCSV(r io.Reader, at GridRange, opts CSVOptions) (err error) {
+	err = sheet.stageCSV(r, at, opts)
+	if err != nil {
+		return
+	}
+	err = sheet.Synchronize()
+	return
+}
+
+func (sheet *Sheet) stageCSV(r io.Reader, at GridRange, opts CSVOptions) error {
+	reader := csv.NewReader(r)
+	reader.Comma = opts.delimiter()
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return err
+	}
+	var columns uint
+	for i, record := range records {
+		if uint(len(record)) > columns {
+			columns = uint(len(record))
+		}
+		if at.EndRowIndex > 0 && at.StartRowIndex+uint(i) >= at.EndRowIndex {
+			return fmt.Errorf("csv has more than %d rows", at.EndRowIndex-at.StartRowIndex)
+		}
+		if at.EndColumnIndex > 0 && at.StartColumnIndex+uint(len(record)) > at.EndColumnIndex {
+			return fmt.Errorf("csv row %d has more than %d columns", i+1, at.EndColumnIndex-at.StartColumnIndex)
+		}
+	}
+	// expand the cells once rather than row by row
+	if row := at.StartRowIndex + uint(len(records)); row > sheet.newMaxRow {
+		sheet.newMaxRow = row
+	}
+	if column := at.StartColumnIndex + columns; column > sheet.newMaxColumn {
+		sheet.newMaxColumn = column
+	}
+	for i, record := range records {
+		for j, value := range record {
+			sheet.Update(int(at.StartRowIndex)+i, int(at.StartColumnIndex)+j, value)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package spreadsheet
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteCSV(t *testing.T) {
+	assert := assert.New(t)
+	var spreadsheet Spreadsheet
+	require.NoError(t, json.Unmarshal([]byte(testSpreadsheetJSON), &spreadsheet))
+	sheet, err := spreadsheet.SheetByID(0)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, sheet.WriteCSV(&buf, CSVOptions{}))
+	assert.Equal(",2020/01/01 12:00:00,0,,FALSE,#DIV/0!\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, sheet.WriteCSV(&buf, CSVOptions{Value: CSVRawValue, Delimiter: '\t'}))
+	assert.Equal("\t43831.5\t0\t\t=1>2\t=1/0\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, sheet.WriteCSV(&buf, CSVOptions{Value: CSVEffectiveValue}))
+	assert.Equal(",43831.5,0,,FALSE,#DIV/0!\n", buf.String())
+
+	sheet.Update(3, 1, "x")
+	buf.Reset()
+	require.NoError(t, sheet.WriteCSV(&buf, CSVOptions{Trim: true}))
+	assert.Equal(",2020/01/01 12:00:00,0,,FALSE,#DIV/0!\n,,,,,\n,,,,,\n,x,,,,\n", buf.String())
+
+	sheet.Update(0, 5, "")
+	sheet.Update(0, 4, "")
+	buf.Reset()
+	require.NoError(t, sheet.WriteCSV(&buf, CSVOptions{Trim: true}))
+	assert.Equal(",2020/01/01 12:00:00,0\n,,\n,,\n,x,\n", buf.String())
+}
+
+func TestStageCSV(t *testing.T) {
+	assert := assert.New(t)
+	s := Sheet{Spreadsheet: &Spreadsheet{}}
+	data := "name;count\n\"a;b\";2\nc\n"
+	require.NoError(t, s.stageCSV(strings.NewReader(data), GridRange{StartRowIndex: 1, StartColumnIndex: 2}, CSVOptions{Delimiter: ';'}))
+
+	assert.Equal("name", s.Rows[1][2].Value)
+	assert.Equal("a;b", s.Rows[2][2].Value)
+	n, ok := s.Rows[2][3].Int()
+	assert.True(ok)
+	assert.Equal(2, n)
+	assert.Equal("c", s.Rows[3][2].Value)
+	assert.Equal(uint(4), s.newMaxRow)
+	assert.Equal(uint(4), s.newMaxColumn)
+
+	r, err := newUpdateRequest(s.Spreadsheet)
+	assert.NoError(err)
+	r.UpdateCells(&s)
+	assert.Equal(3, len(r.body["requests"]))
+
+	err = s.stageCSV(strings.NewReader(data), GridRange{EndRowIndex: 2}, CSVOptions{Delimiter: ';'})
+	assert.Error(err)
+	err = s.stageCSV(strings.NewReader(data), GridRange{StartColumnIndex: 1, EndColumnIndex: 2}, CSVOptions{Delimiter: ';'})
+	assert.Error(err)
+}
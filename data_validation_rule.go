@@ -0,0 +1,37 @@
+package spreadsheet
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DataValidationRule is a data validation rule.
+type DataValidationRule struct {
+	Condition    BooleanCondition `json:"condition"`
+	InputMessage string           `json:"inputMessage,omitempty"`
+	// Strict rejects invalid data if true.
+	Strict bool `json:"strict,omitempty"`
+	// ShowCustomUI shows a dropdown or a checkbox of the condition if true.
+	ShowCustomUI bool `json:"showCustomUi,omitempty"`
+}
+
+// ValidationError is an error of a pending value which violates the data validation rule of the cell.
+type ValidationError struct {
+	Cell Cell
+	Rule DataValidationRule
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid value %q at %s: violates %s", e.Cell.Value, e.Cell.Pos(), e.Rule.Condition.Type)
+}
+
+// ValidationErrors is a list of ValidationError.
+type ValidationErrors []*ValidationError
+
+func (errs ValidationErrors) Error() string {
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "; ")
+}
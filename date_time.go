@@ -0,0 +1,43 @@
+package spreadsheet
+
+import (
+	"math"
+	"time"
+)
+
+// serialEpoch is the day zero of serial numbers of dates in spreadsheets.
+var serialEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// TimeToSerial converts the time to a serial number, the days since 1899-12-30,
+// as shown by the wall clock in the location.
+func TimeToSerial(t time.Time, loc *time.Location) float64 {
+	if loc == nil {
+		loc = time.UTC
+	}
+	t = t.In(loc)
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	seconds := float64(wall.Unix()-serialEpoch.Unix()) + float64(wall.Nanosecond())/1e9
+	return seconds / 86400
+}
+
+// SerialToTime converts the serial number to the time in the location.
+// The time is rounded to milliseconds.
+func SerialToTime(serial float64, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	days := math.Floor(serial)
+	wall := serialEpoch.AddDate(0, 0, int(days)).Add(SerialToDuration(serial - days))
+	return time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), wall.Nanosecond(), loc)
+}
+
+// DurationToSerial converts the duration to a serial number in days.
+func DurationToSerial(d time.Duration) float64 {
+	return d.Seconds() / 86400
+}
+
+// SerialToDuration converts the serial number in days to the duration.
+// The duration is rounded to milliseconds.
+func SerialToDuration(serial float64) time.Duration {
+	return time.Duration(math.Round(serial*86400e3)) * time.Millisecond
+}
@@ -0,0 +1,35 @@
+package spreadsheet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSerialTime(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal(float64(0), TimeToSerial(serialEpoch, time.UTC))
+	assert.Equal(float64(1), TimeToSerial(time.Date(1899, 12, 31, 0, 0, 0, 0, time.UTC), nil))
+	assert.Equal(43831.5, TimeToSerial(time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC), time.UTC))
+	assert.Equal(-1.25, TimeToSerial(time.Date(1899, 12, 28, 18, 0, 0, 0, time.UTC), time.UTC))
+
+	loc := time.FixedZone("JST", 9*60*60)
+	tm := time.Date(2020, 1, 1, 3, 0, 0, 0, time.UTC)
+	serial := TimeToSerial(tm, loc)
+	assert.Equal(43831.5, serial)
+	assert.True(tm.Equal(SerialToTime(serial, loc)))
+	assert.Equal(loc, SerialToTime(serial, loc).Location())
+	assert.Equal(time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC), SerialToTime(serial, nil))
+
+	tm = time.Date(2021, 6, 30, 23, 59, 59, 123e6, loc)
+	assert.True(tm.Equal(SerialToTime(TimeToSerial(tm, loc), loc)))
+	assert.True(time.Date(1899, 12, 28, 18, 0, 0, 0, time.UTC).Equal(SerialToTime(-1.25, time.UTC)))
+}
+
+func TestSerialDuration(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal(0.5, DurationToSerial(12*time.Hour))
+	assert.Equal(36*time.Hour+30*time.Minute, SerialToDuration(1.5+DurationToSerial(30*time.Minute)))
+	assert.Equal(-6*time.Hour, SerialToDuration(-0.25))
+}
@@ -0,0 +1,19 @@
+package spreadsheet
+
+// DelimiterType is the delimiter to split text into columns.
+type DelimiterType string
+
+const (
+	// DelimiterComma splits by ",".
+	DelimiterComma DelimiterType = "COMMA"
+	// DelimiterSemicolon splits by ";".
+	DelimiterSemicolon DelimiterType = "SEMICOLON"
+	// DelimiterPeriod splits by ".".
+	DelimiterPeriod DelimiterType = "PERIOD"
+	// DelimiterSpace splits by " ".
+	DelimiterSpace DelimiterType = "SPACE"
+	// DelimiterCustom splits by a custom delimiter.
+	DelimiterCustom DelimiterType = "CUSTOM"
+	// DelimiterAutodetect detects the delimiter automatically.
+	DelimiterAutodetect DelimiterType = "AUTODETECT"
+)
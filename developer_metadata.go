@@ -0,0 +1,49 @@
+package spreadsheet
+
+// DeveloperMetadata is developer metadata associated with a location or object in a spreadsheet.
+type DeveloperMetadata struct {
+	MetadataID    uint                      `json:"metadataId,omitempty"`
+	MetadataKey   string                    `json:"metadataKey,omitempty"`
+	MetadataValue string                    `json:"metadataValue,omitempty"`
+	Location      DeveloperMetadataLocation `json:"location"`
+	// Visibility is DOCUMENT (visible to all apps with access to the document) or PROJECT (visible only to the project that created it).
+	Visibility string `json:"visibility,omitempty"`
+}
+
+// DeveloperMetadataLocation is a location where metadata may be associated in a spreadsheet.
+// Exactly one of Spreadsheet, SheetID and DimensionRange should be set.
+type DeveloperMetadataLocation struct {
+	// LocationType is one of ROW, COLUMN, SHEET and SPREADSHEET. This field is read-only.
+	LocationType   string          `json:"locationType,omitempty"`
+	Spreadsheet    bool            `json:"spreadsheet,omitempty"`
+	SheetID        *uint           `json:"sheetId,omitempty"`
+	DimensionRange *DimensionRange `json:"dimensionRange,omitempty"`
+}
+
+// DeveloperMetadataLookup selects developer metadata matching all of the specified fields.
+type DeveloperMetadataLookup struct {
+	// LocationType is one of ROW, COLUMN, SHEET and SPREADSHEET.
+	LocationType     string                     `json:"locationType,omitempty"`
+	MetadataLocation *DeveloperMetadataLocation `json:"metadataLocation,omitempty"`
+	// LocationMatchingStrategy is EXACT_LOCATION or INTERSECTING_LOCATION.
+	LocationMatchingStrategy string `json:"locationMatchingStrategy,omitempty"`
+	MetadataID               uint   `json:"metadataId,omitempty"`
+	MetadataKey              string `json:"metadataKey,omitempty"`
+	MetadataValue            string `json:"metadataValue,omitempty"`
+	Visibility               string `json:"visibility,omitempty"`
+}
+
+// DataFilter is a filter that describes what data should be selected or returned from a request.
+// Exactly one of DeveloperMetadataLookup, A1Range and GridRange should be set.
+type DataFilter struct {
+	DeveloperMetadataLookup *DeveloperMetadataLookup `json:"developerMetadataLookup,omitempty"`
+	A1Range                 string                   `json:"a1Range,omitempty"`
+	GridRange               *GridRange               `json:"gridRange,omitempty"`
+}
+
+// ValueRange is the formatted values of a range of a spreadsheet.
+type ValueRange struct {
+	// Range is the range the values cover, in A1 notation.
+	Range  string     `json:"range"`
+	Values [][]string `json:"values"`
+}
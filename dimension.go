@@ -0,0 +1,11 @@
+package spreadsheet
+
+// Dimension indicates which dimension an operation should apply to.
+type Dimension string
+
+const (
+	// DimensionRows operates on the rows of a sheet.
+	DimensionRows Dimension = "ROWS"
+	// DimensionColumns operates on the columns of a sheet.
+	DimensionColumns Dimension = "COLUMNS"
+)
@@ -0,0 +1,10 @@
+package spreadsheet
+
+// DimensionRange is a range along a single dimension on a sheet.
+// All indexes are zero-based. The start index is inclusive and the end index is exclusive.
+type DimensionRange struct {
+	SheetID    uint      `json:"sheetId"`
+	Dimension  Dimension `json:"dimension"`
+	StartIndex uint      `json:"startIndex"`
+	EndIndex   uint      `json:"endIndex"`
+}
@@ -0,0 +1,71 @@
+package spreadsheet
+
+import "strings"
+
+// EmbeddedChart is a chart embedded in a sheet.
+type EmbeddedChart struct {
+	ChartID  uint                   `json:"chartId,omitempty"`
+	Spec     ChartSpec              `json:"spec"`
+	Position EmbeddedObjectPosition `json:"position"`
+}
+
+// EmbeddedObjectPosition is the position of an embedded object such as a chart.
+// Exactly one of SheetID, OverlayPosition and NewSheet should be set.
+type EmbeddedObjectPosition struct {
+	// SheetID is the sheet this is on. Set only if the embedded object is on its own sheet.
+	SheetID         *uint            `json:"sheetId,omitempty"`
+	OverlayPosition *OverlayPosition `json:"overlayPosition,omitempty"`
+	// NewSheet puts the embedded object on a new sheet whose ID is chosen for you.
+	// It is used only when writing.
+	NewSheet bool `json:"newSheet,omitempty"`
+}
+
+// OverlayPosition is the location an object is overlaid on top of a grid.
+type OverlayPosition struct {
+	AnchorCell    GridCoordinate `json:"anchorCell"`
+	OffsetXPixels int            `json:"offsetXPixels,omitempty"`
+	OffsetYPixels int            `json:"offsetYPixels,omitempty"`
+	WidthPixels   int            `json:"widthPixels,omitempty"`
+	HeightPixels  int            `json:"heightPixels,omitempty"`
+}
+
+// fields returns the field mask of the overlay position, which has the anchor cell
+// and the offsets and sizes which are set. The others keep their values when it is updated.
+func (position *OverlayPosition) fields() string {
+	fields := []string{"anchorCell"}
+	for _, field := range []struct {
+		name  string
+		value int
+	}{
+		{"offsetXPixels", position.OffsetXPixels},
+		{"offsetYPixels", position.OffsetYPixels},
+		{"widthPixels", position.WidthPixels},
+		{"heightPixels", position.HeightPixels},
+	} {
+		if field.value != 0 {
+			fields = append(fields, field.name)
+		}
+	}
+	return strings.Join(fields, ",")
+}
+
+// merge returns the overlay position with the offsets and sizes which are not set taken from current.
+func (position *OverlayPosition) merge(current *OverlayPosition) *OverlayPosition {
+	merged := *position
+	if current == nil {
+		return &merged
+	}
+	if merged.OffsetXPixels == 0 {
+		merged.OffsetXPixels = current.OffsetXPixels
+	}
+	if merged.OffsetYPixels == 0 {
+		merged.OffsetYPixels = current.OffsetYPixels
+	}
+	if merged.WidthPixels == 0 {
+		merged.WidthPixels = current.WidthPixels
+	}
+	if merged.HeightPixels == 0 {
+		merged.HeightPixels = current.HeightPixels
+	}
+	return &merged
+}
@@ -1,7 +1,84 @@
 package spreadsheet
 
+import (
+	"fmt"
+	"strings"
+)
+
+// Types of errors in cells.
+const (
+	ErrorTypeError        = "ERROR"
+	ErrorTypeNullValue    = "NULL_VALUE"
+	ErrorTypeDivideByZero = "DIVIDE_BY_ZERO"
+	ErrorTypeValue        = "VALUE"
+	ErrorTypeRef          = "REF"
+	ErrorTypeName         = "NAME"
+	ErrorTypeNum          = "NUM"
+	ErrorTypeNA           = "N_A"
+	// ErrorTypeLoading is the type of a cell still being calculated rather than an error.
+	ErrorTypeLoading = "LOADING"
+)
+
+var errorCodes = map[string]string{
+	ErrorTypeError:        "#ERROR!",
+	ErrorTypeNullValue:    "#NULL!",
+	ErrorTypeDivideByZero: "#DIV/0!",
+	ErrorTypeValue:        "#VALUE!",
+	ErrorTypeRef:          "#REF!",
+	ErrorTypeName:         "#NAME?",
+	ErrorTypeNum:          "#NUM!",
+	ErrorTypeNA:           "#N/A",
+	ErrorTypeLoading:      "Loading...",
+}
+
 // ErrorValue is an error in a cell.
 type ErrorValue struct {
 	Type    string `json:"type"`
 	Message string `json:"message"`
 }
+
+// Error returns the type and the message of the error.
+func (e ErrorValue) Error() string {
+	if e.Message == "" {
+		return e.Type
+	}
+	return e.Type + ": " + e.Message
+}
+
+// Code returns the error as shown in a cell such as #REF!.
+func (e ErrorValue) Code() string {
+	if code, ok := errorCodes[e.Type]; ok {
+		return code
+	}
+	return "#ERROR!"
+}
+
+// FormulaError is an error in the effective value of a cell.
+type FormulaError struct {
+	Sheet string
+	Cell  Cell
+}
+
+// Value returns the error value of the cell.
+func (e *FormulaError) Value() ErrorValue {
+	return e.Cell.effectiveValue.ErrorValue
+}
+
+func (e *FormulaError) Error() string {
+	value := e.Value()
+	if value.Message == "" {
+		return fmt.Sprintf("%s at '%s'!%s", value.Code(), e.Sheet, e.Cell.Pos())
+	}
+	return fmt.Sprintf("%s at '%s'!%s: %s", value.Code(), e.Sheet, e.Cell.Pos(), value.Message)
+}
+
+// FormulaErrors is a list of FormulaError.
+type FormulaErrors []*FormulaError
+
+func (errs FormulaErrors) Error() string {
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "; ")
+}
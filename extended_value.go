@@ -1,5 +1,23 @@
 package spreadsheet
 
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// ValueKind is the kind of value set in an ExtendedValue.
+type ValueKind string
+
+// Kinds of values.
+const (
+	ValueKindNone    ValueKind = ""
+	ValueKindNumber  ValueKind = "NUMBER"
+	ValueKindString  ValueKind = "STRING"
+	ValueKindBool    ValueKind = "BOOL"
+	ValueKindFormula ValueKind = "FORMULA"
+	ValueKindError   ValueKind = "ERROR"
+)
+
 // ExtendedValue is the kinds of value that a cell in a spreadsheet can have.
 type ExtendedValue struct {
 	NumberValue  float64    `json:"numberValue"`
@@ -7,4 +25,110 @@ type ExtendedValue struct {
 	BoolValue    bool       `json:"boolValue"`
 	FormulaValue string     `json:"formulaValue"`
 	ErrorValue   ErrorValue `json:"errorValue"`
+
+	kind ValueKind
+}
+
+// Kind returns which kind of value is set.
+// Values which are not unmarshaled from JSON are inferred from their non-zero fields,
+// so a zero number, false and an empty string are ValueKindNone.
+func (v ExtendedValue) Kind() ValueKind {
+	if v.kind != ValueKindNone {
+		return v.kind
+	}
+	switch {
+	case v.FormulaValue != "":
+		return ValueKindFormula
+	case v.ErrorValue.Type != "":
+		return ValueKindError
+	case v.StringValue != "":
+		return ValueKindString
+	case v.BoolValue:
+		return ValueKindBool
+	case v.NumberValue != 0:
+		return ValueKindNumber
+	}
+	return ValueKindNone
+}
+
+// UnmarshalJSON records which kind of value is set.
+func (v *ExtendedValue) UnmarshalJSON(data []byte) error {
+	type Alias ExtendedValue
+	a := (*Alias)(v)
+	if err := json.Unmarshal(data, a); err != nil {
+		return err
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	v.kind = ValueKindNone
+	for key, kind := range map[string]ValueKind{
+		"numberValue":  ValueKindNumber,
+		"stringValue":  ValueKindString,
+		"boolValue":    ValueKindBool,
+		"formulaValue": ValueKindFormula,
+		"errorValue":   ValueKindError,
+	} {
+		if _, ok := fields[key]; ok {
+			v.kind = kind
+			break
+		}
+	}
+	return nil
+}
+
+// MarshalJSON lets ExtendedValue be marshaled as only the kind of value which is set.
+func (v ExtendedValue) MarshalJSON() ([]byte, error) {
+	value := map[string]interface{}{}
+	switch v.Kind() {
+	case ValueKindFormula:
+		value["formulaValue"] = v.FormulaValue
+	case ValueKindString:
+		value["stringValue"] = v.StringValue
+	case ValueKindBool:
+		value["boolValue"] = v.BoolValue
+	case ValueKindNumber:
+		value["numberValue"] = v.NumberValue
+	case ValueKindError:
+		value["errorValue"] = v.ErrorValue
+	}
+	return json.Marshal(value)
+}
+
+// newExtendedValue makes a value of the kind which a cell infers from the input.
+func newExtendedValue(val string) ExtendedValue {
+	if val == "" {
+		return ExtendedValue{}
+	}
+	switch cellValueType(val) {
+	case "formulaValue":
+		return ExtendedValue{FormulaValue: val, kind: ValueKindFormula}
+	case "numberValue":
+		number, _ := strconv.ParseFloat(val, 64)
+		return ExtendedValue{NumberValue: number, kind: ValueKindNumber}
+	case "boolValue":
+		return ExtendedValue{BoolValue: val == "TRUE", kind: ValueKindBool}
+	}
+	return ExtendedValue{StringValue: val, kind: ValueKindString}
+}
+
+// text returns the value as it is written in a cell.
+func (v ExtendedValue) text() string {
+	switch v.Kind() {
+	case ValueKindFormula:
+		return v.FormulaValue
+	case ValueKindString:
+		return v.StringValue
+	case ValueKindBool:
+		if v.BoolValue {
+			return "TRUE"
+		}
+		return "FALSE"
+	case ValueKindNumber:
+		return strconv.FormatFloat(v.NumberValue, 'f', -1, 64)
+	case ValueKindError:
+		return v.ErrorValue.Code()
+	}
+	return ""
 }
@@ -0,0 +1,43 @@
+package spreadsheet
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtendedValue(t *testing.T) {
+	assert := assert.New(t)
+	for data, kind := range map[string]ValueKind{
+		`{}`:                      ValueKindNone,
+		`{"numberValue": 0}`:      ValueKindNumber,
+		`{"stringValue": ""}`:     ValueKindString,
+		`{"boolValue": false}`:    ValueKindBool,
+		`{"formulaValue": "=A1"}`: ValueKindFormula,
+		`{"errorValue": {"type": "REF", "message": ""}}`: ValueKindError,
+	} {
+		var v ExtendedValue
+		assert.NoError(json.Unmarshal([]byte(data), &v))
+		assert.Equal(kind, v.Kind(), data)
+
+		b, err := json.Marshal(v)
+		assert.NoError(err)
+		assert.JSONEq(data, string(b))
+	}
+
+	assert.Equal(ValueKindNone, ExtendedValue{}.Kind())
+	assert.Equal(ValueKindNumber, ExtendedValue{NumberValue: 1}.Kind())
+	assert.Equal(ValueKindFormula, ExtendedValue{FormulaValue: "=A1", NumberValue: 1}.Kind())
+}
+
+func TestExtendedValueUnsetJSON(t *testing.T) {
+	assert := assert.New(t)
+	b, err := json.Marshal(ExtendedValue{})
+	assert.NoError(err)
+	assert.JSONEq(`{}`, string(b))
+
+	b, err = json.Marshal(PivotGroupSortValueBucket{Buckets: []ExtendedValue{{}, {StringValue: "a"}, {NumberValue: 2}}})
+	assert.NoError(err)
+	assert.JSONEq(`{"valuesIndex": 0, "buckets": [{}, {"stringValue": "a"}, {"numberValue": 2}]}`, string(b))
+}
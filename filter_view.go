@@ -0,0 +1,14 @@
+package spreadsheet
+
+// FilterView is a filter view.
+// Either Range or NamedRangeID is set. A range with only a sheet ID filters the whole sheet.
+type FilterView struct {
+	FilterViewID uint       `json:"filterViewId,omitempty"`
+	Title        string     `json:"title,omitempty"`
+	Range        *GridRange `json:"range,omitempty"`
+	NamedRangeID string     `json:"namedRangeId,omitempty"`
+	SortSpecs    []SortSpec `json:"sortSpecs,omitempty"`
+	// Criteria is the criteria for showing/hiding values per column.
+	// The map's key is the column index, and the value is the criteria for that column.
+	Criteria map[string]FilterCriteria `json:"criteria,omitempty"`
+}
@@ -0,0 +1,30 @@
+package spreadsheet
+
+// GridRange is a range on a sheet.
+// All indexes are zero-based. Start indexes are inclusive and end indexes are exclusive.
+// Missing (zero) end indexes indicate the range is unbounded on that side.
+type GridRange struct {
+	SheetID          uint `json:"sheetId,omitempty"`
+	StartRowIndex    uint `json:"startRowIndex,omitempty"`
+	EndRowIndex      uint `json:"endRowIndex,omitempty"`
+	StartColumnIndex uint `json:"startColumnIndex,omitempty"`
+	EndColumnIndex   uint `json:"endColumnIndex,omitempty"`
+}
+
+func (gridRange GridRange) contains(row, column uint) bool {
+	if row < gridRange.StartRowIndex || (gridRange.EndRowIndex > 0 && row >= gridRange.EndRowIndex) {
+		return false
+	}
+	if column < gridRange.StartColumnIndex || (gridRange.EndColumnIndex > 0 && column >= gridRange.EndColumnIndex) {
+		return false
+	}
+	return true
+}
+
+// GridCoordinate is a coordinate in a sheet.
+// All indexes are zero-based.
+type GridCoordinate struct {
+	SheetID     uint `json:"sheetId,omitempty"`
+	RowIndex    uint `json:"rowIndex,omitempty"`
+	ColumnIndex uint `json:"columnIndex,omitempty"`
+}
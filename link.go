@@ -0,0 +1,12 @@
+package spreadsheet
+
+// Link is an external reference.
+type Link struct {
+	URI string `json:"uri"`
+}
+
+// CellLink is a link found in a cell.
+type CellLink struct {
+	Cell Cell
+	URL  string
+}
@@ -0,0 +1,8 @@
+package spreadsheet
+
+// NamedRange is a named range.
+type NamedRange struct {
+	NamedRangeID string    `json:"namedRangeId,omitempty"`
+	Name         string    `json:"name"`
+	Range        GridRange `json:"range"`
+}
@@ -0,0 +1,20 @@
+package spreadsheet
+
+// Types of number formats.
+const (
+	NumberFormatText       = "TEXT"
+	NumberFormatNumber     = "NUMBER"
+	NumberFormatPercent    = "PERCENT"
+	NumberFormatCurrency   = "CURRENCY"
+	NumberFormatDate       = "DATE"
+	NumberFormatTime       = "TIME"
+	NumberFormatDateTime   = "DATE_TIME"
+	NumberFormatScientific = "SCIENTIFIC"
+)
+
+// NumberFormat is the number format of a cell.
+type NumberFormat struct {
+	// Type is one of TEXT, NUMBER, PERCENT, CURRENCY, DATE, TIME, DATE_TIME and SCIENTIFIC.
+	Type    string `json:"type"`
+	Pattern string `json:"pattern,omitempty"`
+}
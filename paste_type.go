@@ -0,0 +1,31 @@
+package spreadsheet
+
+// PasteType is what kind of data to paste.
+type PasteType string
+
+const (
+	// PasteNormal pastes values, formulas, formats, and merges.
+	PasteNormal PasteType = "PASTE_NORMAL"
+	// PasteValues pastes the values ONLY without formats, formulas, or merges.
+	PasteValues PasteType = "PASTE_VALUES"
+	// PasteFormat pastes the format and data validation only.
+	PasteFormat PasteType = "PASTE_FORMAT"
+	// PasteNoBorders is like PasteNormal but without borders.
+	PasteNoBorders PasteType = "PASTE_NO_BORDERS"
+	// PasteFormula pastes the formulas only.
+	PasteFormula PasteType = "PASTE_FORMULA"
+	// PasteDataValidation pastes the data validation only.
+	PasteDataValidation PasteType = "PASTE_DATA_VALIDATION"
+	// PasteConditionalFormatting pastes the conditional formatting rules only.
+	PasteConditionalFormatting PasteType = "PASTE_CONDITIONAL_FORMATTING"
+)
+
+// PasteOrientation is how a paste operation should be performed.
+type PasteOrientation string
+
+const (
+	// PasteOrientationNormal pastes normally.
+	PasteOrientationNormal PasteOrientation = "NORMAL"
+	// PasteOrientationTranspose pastes transposed, where all rows become columns and vice versa.
+	PasteOrientationTranspose PasteOrientation = "TRANSPOSE"
+)
@@ -0,0 +1,94 @@
+package spreadsheet
+
+import "encoding/json"
+
+// PivotTable is a pivot table.
+// It is anchored at the top left cell of its output.
+type PivotTable struct {
+	Source  GridRange    `json:"source"`
+	Rows    []PivotGroup `json:"rows,omitempty"`
+	Columns []PivotGroup `json:"columns,omitempty"`
+	// Criteria is an optional mapping of filters per source column offset.
+	// The map's key is the column offset of the source range, and the value is the criteria for that column.
+	Criteria map[string]PivotFilterCriteria `json:"criteria,omitempty"`
+	Values   []PivotValue                   `json:"values,omitempty"`
+	// ValueLayout is whether values should be listed HORIZONTAL (as columns) or VERTICAL (as rows).
+	ValueLayout string `json:"valueLayout,omitempty"`
+}
+
+// PivotGroup is a single grouping (either row or column) in a pivot table.
+type PivotGroup struct {
+	// SourceColumnOffset is the column offset of the source range that this grouping is based on.
+	SourceColumnOffset int                       `json:"sourceColumnOffset"`
+	ShowTotals         bool                      `json:"showTotals,omitempty"`
+	ValueMetadata      []PivotGroupValueMetadata `json:"valueMetadata,omitempty"`
+	SortOrder          SortOrder                 `json:"sortOrder,omitempty"`
+	// ValueBucket is the bucket of the opposite pivot group to sort by.
+	// If not specified, sorting is alphabetical by this group's values.
+	ValueBucket *PivotGroupSortValueBucket `json:"valueBucket,omitempty"`
+	Label       string                     `json:"label,omitempty"`
+}
+
+// PivotGroupValueMetadata is metadata about a value in a pivot grouping.
+type PivotGroupValueMetadata struct {
+	Value     ExtendedValue `json:"value"`
+	Collapsed bool          `json:"collapsed,omitempty"`
+}
+
+// PivotGroupSortValueBucket is information about which values in a pivot group should be used for sorting.
+type PivotGroupSortValueBucket struct {
+	// ValuesIndex is the offset in the PivotTable.Values list which the values in this grouping should be sorted by.
+	ValuesIndex int `json:"valuesIndex"`
+	// Buckets determines the bucket from which values are chosen to sort.
+	Buckets []ExtendedValue `json:"buckets,omitempty"`
+}
+
+// PivotFilterCriteria is criteria for showing/hiding rows in a pivot table.
+type PivotFilterCriteria struct {
+	VisibleValues []string `json:"visibleValues,omitempty"`
+}
+
+// PivotValueSummarizeFunction is a function to summarize a pivot value.
+type PivotValueSummarizeFunction string
+
+// The functions to summarize pivot values.
+const (
+	SummarizeSum         PivotValueSummarizeFunction = "SUM"
+	SummarizeCountA      PivotValueSummarizeFunction = "COUNTA"
+	SummarizeCount       PivotValueSummarizeFunction = "COUNT"
+	SummarizeCountUnique PivotValueSummarizeFunction = "COUNTUNIQUE"
+	SummarizeAverage     PivotValueSummarizeFunction = "AVERAGE"
+	SummarizeMax         PivotValueSummarizeFunction = "MAX"
+	SummarizeMin         PivotValueSummarizeFunction = "MIN"
+	SummarizeMedian      PivotValueSummarizeFunction = "MEDIAN"
+	SummarizeProduct     PivotValueSummarizeFunction = "PRODUCT"
+	SummarizeStdev       PivotValueSummarizeFunction = "STDEV"
+	SummarizeStdevP      PivotValueSummarizeFunction = "STDEVP"
+	SummarizeVar         PivotValueSummarizeFunction = "VAR"
+	SummarizeVarP        PivotValueSummarizeFunction = "VARP"
+	// SummarizeCustom is valid only if Formula is set.
+	SummarizeCustom PivotValueSummarizeFunction = "CUSTOM"
+)
+
+// PivotValue is the settings for one value in a pivot table.
+// Exactly one of SourceColumnOffset and Formula is used.
+type PivotValue struct {
+	SourceColumnOffset int                         `json:"sourceColumnOffset,omitempty"`
+	Formula            string                      `json:"formula,omitempty"`
+	SummarizeFunction  PivotValueSummarizeFunction `json:"summarizeFunction"`
+	Name               string                      `json:"name,omitempty"`
+}
+
+// MarshalJSON lets PivotValue be marshaled with SourceColumnOffset unless Formula is set,
+// so that a value of the first source column is sent with its offset 0.
+func (v PivotValue) MarshalJSON() ([]byte, error) {
+	type pivotValue PivotValue
+	value := struct {
+		SourceColumnOffset *int `json:"sourceColumnOffset,omitempty"`
+		pivotValue
+	}{pivotValue: pivotValue(v)}
+	if v.Formula == "" {
+		value.SourceColumnOffset = &v.SourceColumnOffset
+	}
+	return json.Marshal(value)
+}
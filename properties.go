@@ -2,9 +2,20 @@ package spreadsheet
 
 // Properties is properties of a spreadsheet.
 type Properties struct {
-	Title      string `json:"title"`
-	Locale     string `json:"locale"`
+	Title  string `json:"title"`
+	Locale string `json:"locale"`
+	// AutoRecalc is one of ON_CHANGE, MINUTE and HOUR.
 	AutoRecalc string `json:"autoRecalc"`
-	TimeZone   string `json:"timezone"`
-	// DefaultFormat *CellFormat `defaultFormat`
+	// TimeZone is the time zone in CLDR format such as America/New_York.
+	TimeZone      string      `json:"timeZone"`
+	DefaultFormat *CellFormat `json:"defaultFormat,omitempty"`
+	// IterativeCalculationSettings determines whether circular references are resolved with iterative calculation.
+	// Circular references are errors if it is nil.
+	IterativeCalculationSettings *IterativeCalculationSettings `json:"iterativeCalculationSettings,omitempty"`
+}
+
+// IterativeCalculationSettings is settings to control how circular dependencies are resolved with iterative calculation.
+type IterativeCalculationSettings struct {
+	MaxIterations        int     `json:"maxIterations,omitempty"`
+	ConvergenceThreshold float64 `json:"convergenceThreshold,omitempty"`
 }
@@ -0,0 +1,25 @@
+package spreadsheet
+
+// ProtectedRange is a protected range.
+// Either Range or NamedRangeID is set. A range with only a sheet ID protects the whole sheet.
+type ProtectedRange struct {
+	ProtectedRangeID uint       `json:"protectedRangeId,omitempty"`
+	Range            *GridRange `json:"range,omitempty"`
+	NamedRangeID     string     `json:"namedRangeId,omitempty"`
+	Description      string     `json:"description,omitempty"`
+	// WarningOnly shows a warning when editing instead of rejecting it.
+	// Editors are ignored if true.
+	WarningOnly bool `json:"warningOnly,omitempty"`
+	// RequestingUserCanEdit is true if the user who requested this protected range can edit it.
+	// This field is read-only.
+	RequestingUserCanEdit bool        `json:"requestingUserCanEdit,omitempty"`
+	UnprotectedRanges     []GridRange `json:"unprotectedRanges,omitempty"`
+	Editors               *Editors    `json:"editors,omitempty"`
+}
+
+// Editors is the editors of a protected range.
+type Editors struct {
+	Users              []string `json:"users,omitempty"`
+	Groups             []string `json:"groups,omitempty"`
+	DomainUsersCanEdit bool     `json:"domainUsersCanEdit,omitempty"`
+}
@@ -3,10 +3,12 @@ package spreadsheet
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strings"
 	"sync"
 	"time"
 
@@ -60,19 +62,10 @@ type Service struct {
 	configForSpreadsheetByID map[string]spreadsheetConfig
 }
 
-// CreateSpreadsheet creates a spreadsheet with the given title
+// CreateSpreadsheet creates a spreadsheet with the given title.
+// The sheets are created with their cells, merges and column widths if they have any.
 func (s *Service) CreateSpreadsheet(spreadsheet Spreadsheet) (resp Spreadsheet, err error) {
-	sheets := make([]map[string]interface{}, 1)
-	for s := range spreadsheet.Sheets {
-		sheet := spreadsheet.Sheets[s]
-		sheets = append(sheets, map[string]interface{}{"properties": map[string]interface{}{"title": sheet.Properties.Title}})
-	}
-	body, err := s.post("/spreadsheets", map[string]interface{}{
-		"properties": map[string]interface{}{
-			"title": spreadsheet.Properties.Title,
-		},
-		"sheets": sheets,
-	})
+	body, err := s.post("/spreadsheets", spreadsheet.createParams())
 	if err != nil {
 		return
 	}
@@ -84,9 +77,10 @@ func (s *Service) CreateSpreadsheet(spreadsheet Spreadsheet) (resp Spreadsheet,
 }
 
 type spreadsheetConfig struct {
-	cacheInterval     time.Duration
-	lastCachedAt      time.Time
-	cachedSpreadsheet Spreadsheet
+	cacheInterval       time.Duration
+	lastCachedAt        time.Time
+	cachedSpreadsheet   Spreadsheet
+	failOnFormulaErrors bool
 }
 
 // FetchSpreadsheetOption is the option for FetchSpreadsheet function
@@ -99,6 +93,15 @@ func WithCache(interval time.Duration) FetchSpreadsheetOption {
 	}
 }
 
+// WithFormulaErrors gives an option for FetchSpreadsheet function to fail
+// with FormulaErrors if any cell of the spreadsheet has an error such as #REF!.
+// The spreadsheet is returned together with the error.
+func WithFormulaErrors() FetchSpreadsheetOption {
+	return func(config *spreadsheetConfig) {
+		config.failOnFormulaErrors = true
+	}
+}
+
 // FetchSpreadsheet fetches the spreadsheet by the id.
 func (s *Service) FetchSpreadsheet(id string, options ...FetchSpreadsheetOption) (spreadsheet Spreadsheet, err error) {
 	s.m.RLock()
@@ -107,13 +110,22 @@ func (s *Service) FetchSpreadsheet(id string, options ...FetchSpreadsheetOption)
 	for _, o := range options {
 		o(&config)
 	}
+	failOnFormulaErrors := config.failOnFormulaErrors
+	config.failOnFormulaErrors = false
+	defer func() {
+		if err == nil && failOnFormulaErrors {
+			if errs := spreadsheet.FormulaErrors(); len(errs) > 0 {
+				err = errs
+			}
+		}
+	}()
 
 	if config.cacheInterval > 0 && time.Now().Sub(config.lastCachedAt.Add(config.cacheInterval)) <= 0 {
 		// use cache
 		return config.cachedSpreadsheet, nil
 	}
 
-	fields := "spreadsheetId,properties.title,sheets(properties,data.rowData.values(userEnteredValue,effectiveValue,formattedValue,note))"
+	fields := "spreadsheetId,properties,namedRanges,developerMetadata,sheets(properties,developerMetadata,conditionalFormats,protectedRanges,basicFilter,filterViews,bandedRanges,charts,merges,data(rowData.values(userEnteredValue,effectiveValue,formattedValue,userEnteredFormat,hyperlink,note,textFormatRuns,dataValidation,pivotTable),rowMetadata,columnMetadata))"
 	fields = url.QueryEscape(fields)
 	path := fmt.Sprintf("/spreadsheets/%s?fields=%s", id, fields)
 	body, err := s.get(path)
@@ -144,7 +156,62 @@ func (s *Service) ReloadSpreadsheet(spreadsheet *Spreadsheet) (err error) {
 		return
 	}
 	spreadsheet.Properties = newSpreadsheet.Properties
+	spreadsheet.syncedProperties = newSpreadsheet.syncedProperties
 	spreadsheet.Sheets = newSpreadsheet.Sheets
+	spreadsheet.NamedRanges = newSpreadsheet.NamedRanges
+	spreadsheet.DeveloperMetadata = newSpreadsheet.DeveloperMetadata
+	for i := range spreadsheet.Sheets {
+		spreadsheet.Sheets[i].Spreadsheet = spreadsheet
+	}
+	return
+}
+
+// AttachSpreadsheet attaches the spreadsheet loaded from a snapshot or a file to the service,
+// so that it can be synchronized and reloaded with the service.
+func (s *Service) AttachSpreadsheet(spreadsheet *Spreadsheet) {
+	spreadsheet.service = s
+	spreadsheet.cached = false
+	for i := range spreadsheet.Sheets {
+		spreadsheet.Sheets[i].Spreadsheet = spreadsheet
+	}
+}
+
+// reloadSheet reloads the spreadsheet the sheet belongs to and refreshes the sheet in place.
+func (s *Service) reloadSheet(sheet *Sheet) (err error) {
+	spreadsheet := sheet.Spreadsheet
+	err = s.ReloadSpreadsheet(spreadsheet)
+	if err != nil {
+		return
+	}
+	newSheet, err := spreadsheet.SheetByID(sheet.Properties.ID)
+	if err != nil {
+		return
+	}
+	*sheet = *newSheet
+	return
+}
+
+// UpdateSpreadsheetProperties updates the changed properties of the spreadsheet.
+// The properties are compared with the ones last fetched or updated,
+// so the changes made through the pointers of spreadsheet.Properties are also sent.
+func (s *Service) UpdateSpreadsheetProperties(spreadsheet *Spreadsheet, properties Properties) (err error) {
+	r, err := newUpdateRequest(spreadsheet)
+	if err != nil {
+		return
+	}
+	r.UpdateSpreadsheetProperties(&properties)
+	if len(r.body["requests"]) == 0 {
+		return
+	}
+	err = r.Do()
+	if err != nil {
+		return
+	}
+	err = deepCopy(&spreadsheet.Properties, properties)
+	if err != nil {
+		return
+	}
+	err = deepCopy(&spreadsheet.syncedProperties, properties)
 	return
 }
 
@@ -190,69 +257,906 @@ func (s *Service) DeleteSheet(spreadsheet *Spreadsheet, sheetID uint) (err error
 	return
 }
 
-// SyncSheet updates sheet
-func (s *Service) SyncSheet(sheet *Sheet) (err error) {
-	if sheet.newMaxRow > sheet.Properties.GridProperties.RowCount ||
-		sheet.newMaxColumn > sheet.Properties.GridProperties.ColumnCount {
-		err = s.ExpandSheet(sheet, sheet.newMaxRow, sheet.newMaxColumn)
-		if err != nil {
-			return
+// CopyPaste copies data from the source range to the destination range.
+// The ranges may belong to different sheets of the spreadsheet.
+func (s *Service) CopyPaste(spreadsheet *Spreadsheet, source, destination GridRange, pasteType PasteType, orientation PasteOrientation) (err error) {
+	r, err := newUpdateRequest(spreadsheet)
+	if err != nil {
+		return
+	}
+	err = r.CopyPaste(source, destination, pasteType, orientation).Do()
+	if err != nil {
+		return
+	}
+	err = s.ReloadSpreadsheet(spreadsheet)
+	return
+}
+
+// CutPaste moves data from the source range to the destination.
+// The destination may belong to a different sheet of the spreadsheet.
+func (s *Service) CutPaste(spreadsheet *Spreadsheet, source GridRange, destination GridCoordinate, pasteType PasteType) (err error) {
+	r, err := newUpdateRequest(spreadsheet)
+	if err != nil {
+		return
+	}
+	err = r.CutPaste(source, destination, pasteType).Do()
+	if err != nil {
+		return
+	}
+	err = s.ReloadSpreadsheet(spreadsheet)
+	return
+}
+
+// PasteData pastes the delimited data into the sheet with its top left corner at the row and column.
+func (s *Service) PasteData(sheet *Sheet, row, column int, data, delimiter string, pasteType PasteType) (err error) {
+	return s.pasteData(sheet, row, column, data, delimiter, false, pasteType)
+}
+
+// PasteHTML pastes the HTML data into the sheet with its top left corner at the row and column.
+func (s *Service) PasteHTML(sheet *Sheet, row, column int, html string, pasteType PasteType) (err error) {
+	return s.pasteData(sheet, row, column, html, "", true, pasteType)
+}
+
+func (s *Service) pasteData(sheet *Sheet, row, column int, data, delimiter string, html bool, pasteType PasteType) (err error) {
+	r, err := newUpdateRequest(sheet.Spreadsheet)
+	if err != nil {
+		return
+	}
+	coordinate := GridCoordinate{
+		SheetID:     sheet.Properties.ID,
+		RowIndex:    uint(row),
+		ColumnIndex: uint(column),
+	}
+	err = r.PasteData(coordinate, data, delimiter, html, pasteType).Do()
+	if err != nil {
+		return
+	}
+	err = s.reloadSheet(sheet)
+	return
+}
+
+// TextToColumns splits the text of the source range into columns.
+// The source range must span exactly one column.
+func (s *Service) TextToColumns(sheet *Sheet, source GridRange, delimiterType DelimiterType, delimiter string) (err error) {
+	if delimiterType == DelimiterCustom && delimiter == "" {
+		err = errors.New("delimiter must not be empty for the custom delimiter type")
+		return
+	}
+	source.SheetID = sheet.Properties.ID
+	r, err := newUpdateRequest(sheet.Spreadsheet)
+	if err != nil {
+		return
+	}
+	err = r.TextToColumns(source, delimiterType, delimiter).Do()
+	if err != nil {
+		return
+	}
+	err = s.reloadSheet(sheet)
+	return
+}
+
+// AutoFill fills in the empty cells of the range based on the data in it.
+func (s *Service) AutoFill(sheet *Sheet, gridRange GridRange, useAlternateSeries bool) (err error) {
+	gridRange.SheetID = sheet.Properties.ID
+	r, err := newUpdateRequest(sheet.Spreadsheet)
+	if err != nil {
+		return
+	}
+	err = r.AutoFill(gridRange, useAlternateSeries).Do()
+	if err != nil {
+		return
+	}
+	err = s.reloadSheet(sheet)
+	return
+}
+
+// AutoFillSourceAndDestination extends the data of the source by the fill length.
+func (s *Service) AutoFillSourceAndDestination(sheet *Sheet, sourceAndDestination SourceAndDestination, useAlternateSeries bool) (err error) {
+	sourceAndDestination.Source.SheetID = sheet.Properties.ID
+	r, err := newUpdateRequest(sheet.Spreadsheet)
+	if err != nil {
+		return
+	}
+	err = r.AutoFillSourceAndDestination(sourceAndDestination, useAlternateSeries).Do()
+	if err != nil {
+		return
+	}
+	err = s.reloadSheet(sheet)
+	return
+}
+
+// AddNamedRange adds a named range of the grid range to the spreadsheet
+func (s *Service) AddNamedRange(spreadsheet *Spreadsheet, name string, gridRange GridRange) (namedRange NamedRange, err error) {
+	r, err := newUpdateRequest(spreadsheet)
+	if err != nil {
+		return
+	}
+	res, err := r.AddNamedRange(NamedRange{Name: name, Range: gridRange}).DoWithResponse()
+	if err != nil {
+		return
+	}
+	if len(res.Replies) == 0 || res.Replies[0].AddNamedRange == nil {
+		err = errors.New("no reply for the added named range")
+		return
+	}
+	namedRange = res.Replies[0].AddNamedRange.NamedRange
+	spreadsheet.NamedRanges = append(spreadsheet.NamedRanges, namedRange)
+	return
+}
+
+// UpdateNamedRange updates the name and the range of the named range which has the same ID
+func (s *Service) UpdateNamedRange(spreadsheet *Spreadsheet, namedRange NamedRange) (err error) {
+	current, err := spreadsheet.NamedRangeByID(namedRange.NamedRangeID)
+	if err != nil {
+		return
+	}
+	r, err := newUpdateRequest(spreadsheet)
+	if err != nil {
+		return
+	}
+	r.UpdateNamedRange(*current, namedRange)
+	if len(r.body["requests"]) == 0 {
+		return
+	}
+	err = r.Do()
+	if err != nil {
+		return
+	}
+	*current = namedRange
+	return
+}
+
+// DeleteNamedRange deletes the named range from the spreadsheet
+func (s *Service) DeleteNamedRange(spreadsheet *Spreadsheet, namedRangeID string) (err error) {
+	r, err := newUpdateRequest(spreadsheet)
+	if err != nil {
+		return
+	}
+	err = r.DeleteNamedRange(namedRangeID).Do()
+	if err != nil {
+		return
+	}
+	for i, namedRange := range spreadsheet.NamedRanges {
+		if namedRange.NamedRangeID == namedRangeID {
+			spreadsheet.NamedRanges = append(spreadsheet.NamedRanges[:i], spreadsheet.NamedRanges[i+1:]...)
+			break
 		}
 	}
+	return
+}
+
+// AddConditionalFormatRule adds the conditional format rule to the sheet at the index.
+// The ranges of the rule are set to the sheet.
+func (s *Service) AddConditionalFormatRule(sheet *Sheet, rule ConditionalFormatRule, index int) (err error) {
+	if index < 0 || index > len(sheet.ConditionalFormats) {
+		err = errors.New("conditional format rule index out of range")
+		return
+	}
+	rule = sheet.conditionalFormatRule(rule)
 	r, err := newUpdateRequest(sheet.Spreadsheet)
 	if err != nil {
 		return
 	}
-	err = r.UpdateCells(sheet).Do()
+	err = r.AddConditionalFormatRule(rule, index).Do()
 	if err != nil {
 		return
 	}
-	sheet.modifiedCells = []*Cell{}
-	sheet.Properties.GridProperties.RowCount = sheet.newMaxRow
-	sheet.Properties.GridProperties.ColumnCount = sheet.newMaxColumn
+	rules := append([]ConditionalFormatRule{}, sheet.ConditionalFormats[:index]...)
+	rules = append(rules, rule)
+	sheet.ConditionalFormats = append(rules, sheet.ConditionalFormats[index:]...)
 	return
 }
 
-// ExpandSheet expands the range of the sheet
-func (s *Service) ExpandSheet(sheet *Sheet, row, column uint) (err error) {
-	props := sheet.Properties
-	props.GridProperties.RowCount = row
-	props.GridProperties.ColumnCount = column
+// UpdateConditionalFormatRule replaces the conditional format rule of the sheet at the index.
+func (s *Service) UpdateConditionalFormatRule(sheet *Sheet, index int, rule ConditionalFormatRule) (err error) {
+	if index < 0 || index >= len(sheet.ConditionalFormats) {
+		err = errors.New("conditional format rule index out of range")
+		return
+	}
+	rule = sheet.conditionalFormatRule(rule)
+	r, err := newUpdateRequest(sheet.Spreadsheet)
+	if err != nil {
+		return
+	}
+	err = r.UpdateConditionalFormatRule(sheet, index, rule).Do()
+	if err != nil {
+		return
+	}
+	sheet.ConditionalFormats[index] = rule
+	return
+}
 
+// MoveConditionalFormatRule moves the conditional format rule of the sheet at the index to the new index.
+func (s *Service) MoveConditionalFormatRule(sheet *Sheet, index, newIndex int) (err error) {
+	if index < 0 || index >= len(sheet.ConditionalFormats) ||
+		newIndex < 0 || newIndex >= len(sheet.ConditionalFormats) {
+		err = errors.New("conditional format rule index out of range")
+		return
+	}
 	r, err := newUpdateRequest(sheet.Spreadsheet)
 	if err != nil {
 		return
 	}
-	err = r.UpdateSheetProperties(sheet, &props).Do()
+	err = r.MoveConditionalFormatRule(sheet, index, newIndex).Do()
 	if err != nil {
 		return
 	}
-	sheet.newMaxRow = row
-	sheet.newMaxColumn = column
+	rule := sheet.ConditionalFormats[index]
+	rules := append(sheet.ConditionalFormats[:index], sheet.ConditionalFormats[index+1:]...)
+	rules = append(rules[:newIndex], append([]ConditionalFormatRule{rule}, rules[newIndex:]...)...)
+	sheet.ConditionalFormats = rules
 	return
 }
 
-// DeleteRows deletes rows from the sheet
-func (s *Service) DeleteRows(sheet *Sheet, start, end int) (err error) {
-	sheet.Properties.GridProperties.RowCount -= uint(end - start)
-	sheet.newMaxRow -= uint(end - start)
+// DeleteConditionalFormatRule deletes the conditional format rule of the sheet at the index.
+func (s *Service) DeleteConditionalFormatRule(sheet *Sheet, index int) (err error) {
+	if index < 0 || index >= len(sheet.ConditionalFormats) {
+		err = errors.New("conditional format rule index out of range")
+		return
+	}
 	r, err := newUpdateRequest(sheet.Spreadsheet)
 	if err != nil {
 		return
 	}
-	err = r.DeleteDimension(sheet, "ROWS", start, end).Do()
+	err = r.DeleteConditionalFormatRule(sheet, index).Do()
+	if err != nil {
+		return
+	}
+	sheet.ConditionalFormats = append(sheet.ConditionalFormats[:index], sheet.ConditionalFormats[index+1:]...)
 	return
 }
 
-// DeleteColumns deletes columns from the sheet
-func (s *Service) DeleteColumns(sheet *Sheet, start, end int) (err error) {
-	sheet.Properties.GridProperties.ColumnCount -= uint(end - start)
-	sheet.newMaxRow -= uint(end - start)
+// SetDataValidation sets the data validation rule to all cells in the range of the sheet.
+// A nil rule clears the data validation of the cells.
+func (s *Service) SetDataValidation(sheet *Sheet, gridRange GridRange, rule *DataValidationRule) (err error) {
+	gridRange.SheetID = sheet.Properties.ID
+	r, err := newUpdateRequest(sheet.Spreadsheet)
+	if err != nil {
+		return
+	}
+	err = r.SetDataValidation(gridRange, rule).Do()
+	if err != nil {
+		return
+	}
+	sheet.setDataValidation(gridRange, rule)
+	return
+}
+
+// AddProtectedRange adds the protected range to the sheet and returns it with the assigned ID.
+// The whole sheet is protected if neither the range nor the named range ID is set.
+func (s *Service) AddProtectedRange(sheet *Sheet, protectedRange ProtectedRange) (added ProtectedRange, err error) {
+	protectedRange = sheet.protectedRange(protectedRange)
+	r, err := newUpdateRequest(sheet.Spreadsheet)
+	if err != nil {
+		return
+	}
+	res, err := r.AddProtectedRange(protectedRange).DoWithResponse()
+	if err != nil {
+		return
+	}
+	if len(res.Replies) == 0 || res.Replies[0].AddProtectedRange == nil {
+		err = errors.New("no reply for the added protected range")
+		return
+	}
+	added = res.Replies[0].AddProtectedRange.ProtectedRange
+	var stored ProtectedRange
+	err = deepCopy(&stored, added)
+	if err != nil {
+		return
+	}
+	sheet.ProtectedRanges = append(sheet.ProtectedRanges, stored)
+	return
+}
+
+// UpdateProtectedRange updates the protected range of the sheet which has the same ID
+func (s *Service) UpdateProtectedRange(sheet *Sheet, protectedRange ProtectedRange) (err error) {
+	current, err := sheet.ProtectedRangeByID(protectedRange.ProtectedRangeID)
+	if err != nil {
+		return
+	}
+	protectedRange = sheet.protectedRange(protectedRange)
+	r, err := newUpdateRequest(sheet.Spreadsheet)
+	if err != nil {
+		return
+	}
+	r.UpdateProtectedRange(*current, protectedRange)
+	if len(r.body["requests"]) == 0 {
+		return
+	}
+	err = r.Do()
+	if err != nil {
+		return
+	}
+	protectedRange.RequestingUserCanEdit = current.RequestingUserCanEdit
+	err = deepCopy(current, protectedRange)
+	return
+}
+
+// DeleteProtectedRange deletes the protected range from the sheet
+func (s *Service) DeleteProtectedRange(sheet *Sheet, protectedRangeID uint) (err error) {
+	r, err := newUpdateRequest(sheet.Spreadsheet)
+	if err != nil {
+		return
+	}
+	err = r.DeleteProtectedRange(protectedRangeID).Do()
+	if err != nil {
+		return
+	}
+	for i, protectedRange := range sheet.ProtectedRanges {
+		if protectedRange.ProtectedRangeID == protectedRangeID {
+			sheet.ProtectedRanges = append(sheet.ProtectedRanges[:i], sheet.ProtectedRanges[i+1:]...)
+			break
+		}
+	}
+	return
+}
+
+// SetBasicFilter sets the basic filter of the sheet.
+// The rows hidden by the filter are reloaded.
+func (s *Service) SetBasicFilter(sheet *Sheet, filter BasicFilter) (err error) {
+	filter.Range.SheetID = sheet.Properties.ID
+	r, err := newUpdateRequest(sheet.Spreadsheet)
+	if err != nil {
+		return
+	}
+	err = r.SetBasicFilter(filter).Do()
+	if err != nil {
+		return
+	}
+	err = s.reloadSheet(sheet)
+	return
+}
+
+// ClearBasicFilter clears the basic filter of the sheet.
+func (s *Service) ClearBasicFilter(sheet *Sheet) (err error) {
 	r, err := newUpdateRequest(sheet.Spreadsheet)
 	if err != nil {
 		return
 	}
-	err = r.DeleteDimension(sheet, "COLUMNS", start, end).Do()
+	err = r.ClearBasicFilter(sheet).Do()
+	if err != nil {
+		return
+	}
+	sheet.BasicFilter = nil
+	for i := range sheet.rowMetadata {
+		sheet.rowMetadata[i].HiddenByFilter = false
+	}
+	return
+}
+
+// AddFilterView adds the filter view to the sheet and returns it with the assigned ID.
+// The whole sheet is filtered if neither the range nor the named range ID is set.
+func (s *Service) AddFilterView(sheet *Sheet, filterView FilterView) (added FilterView, err error) {
+	filterView = sheet.filterView(filterView)
+	r, err := newUpdateRequest(sheet.Spreadsheet)
+	if err != nil {
+		return
+	}
+	res, err := r.AddFilterView(filterView).DoWithResponse()
+	if err != nil {
+		return
+	}
+	if len(res.Replies) == 0 || res.Replies[0].AddFilterView == nil {
+		err = errors.New("no reply for the added filter view")
+		return
+	}
+	added = res.Replies[0].AddFilterView.Filter
+	var stored FilterView
+	err = deepCopy(&stored, added)
+	if err != nil {
+		return
+	}
+	sheet.FilterViews = append(sheet.FilterViews, stored)
+	return
+}
+
+// UpdateFilterView updates the filter view of the sheet which has the same ID
+func (s *Service) UpdateFilterView(sheet *Sheet, filterView FilterView) (err error) {
+	current, err := sheet.FilterViewByID(filterView.FilterViewID)
+	if err != nil {
+		return
+	}
+	filterView = sheet.filterView(filterView)
+	r, err := newUpdateRequest(sheet.Spreadsheet)
+	if err != nil {
+		return
+	}
+	r.UpdateFilterView(*current, filterView)
+	if len(r.body["requests"]) == 0 {
+		return
+	}
+	err = r.Do()
+	if err != nil {
+		return
+	}
+	err = deepCopy(current, filterView)
+	return
+}
+
+// DuplicateFilterView duplicates the filter view of the sheet and returns the new one
+func (s *Service) DuplicateFilterView(sheet *Sheet, filterViewID uint) (duplicated FilterView, err error) {
+	r, err := newUpdateRequest(sheet.Spreadsheet)
+	if err != nil {
+		return
+	}
+	res, err := r.DuplicateFilterView(filterViewID).DoWithResponse()
+	if err != nil {
+		return
+	}
+	if len(res.Replies) == 0 || res.Replies[0].DuplicateFilterView == nil {
+		err = errors.New("no reply for the duplicated filter view")
+		return
+	}
+	duplicated = res.Replies[0].DuplicateFilterView.Filter
+	var stored FilterView
+	err = deepCopy(&stored, duplicated)
+	if err != nil {
+		return
+	}
+	sheet.FilterViews = append(sheet.FilterViews, stored)
+	return
+}
+
+// DeleteFilterView deletes the filter view from the sheet
+func (s *Service) DeleteFilterView(sheet *Sheet, filterViewID uint) (err error) {
+	r, err := newUpdateRequest(sheet.Spreadsheet)
+	if err != nil {
+		return
+	}
+	err = r.DeleteFilterView(filterViewID).Do()
+	if err != nil {
+		return
+	}
+	for i, filterView := range sheet.FilterViews {
+		if filterView.FilterViewID == filterViewID {
+			sheet.FilterViews = append(sheet.FilterViews[:i], sheet.FilterViews[i+1:]...)
+			break
+		}
+	}
+	return
+}
+
+// AddBanding adds the banded range to the sheet and returns it with the assigned ID.
+func (s *Service) AddBanding(sheet *Sheet, bandedRange BandedRange) (added BandedRange, err error) {
+	bandedRange.Range.SheetID = sheet.Properties.ID
+	r, err := newUpdateRequest(sheet.Spreadsheet)
+	if err != nil {
+		return
+	}
+	res, err := r.AddBanding(bandedRange).DoWithResponse()
+	if err != nil {
+		return
+	}
+	if len(res.Replies) == 0 || res.Replies[0].AddBanding == nil {
+		err = errors.New("no reply for the added banded range")
+		return
+	}
+	added = res.Replies[0].AddBanding.BandedRange
+	var stored BandedRange
+	err = deepCopy(&stored, added)
+	if err != nil {
+		return
+	}
+	sheet.BandedRanges = append(sheet.BandedRanges, stored)
+	return
+}
+
+// UpdateBanding updates the banded range of the sheet which has the same ID
+func (s *Service) UpdateBanding(sheet *Sheet, bandedRange BandedRange) (err error) {
+	current, err := sheet.BandedRangeByID(bandedRange.BandedRangeID)
+	if err != nil {
+		return
+	}
+	bandedRange.Range.SheetID = sheet.Properties.ID
+	r, err := newUpdateRequest(sheet.Spreadsheet)
+	if err != nil {
+		return
+	}
+	r.UpdateBanding(*current, bandedRange)
+	if len(r.body["requests"]) == 0 {
+		return
+	}
+	err = r.Do()
+	if err != nil {
+		return
+	}
+	err = deepCopy(current, bandedRange)
+	return
+}
+
+// DeleteBanding deletes the banded range from the sheet
+func (s *Service) DeleteBanding(sheet *Sheet, bandedRangeID uint) (err error) {
+	r, err := newUpdateRequest(sheet.Spreadsheet)
+	if err != nil {
+		return
+	}
+	err = r.DeleteBanding(bandedRangeID).Do()
+	if err != nil {
+		return
+	}
+	for i, bandedRange := range sheet.BandedRanges {
+		if bandedRange.BandedRangeID == bandedRangeID {
+			sheet.BandedRanges = append(sheet.BandedRanges[:i], sheet.BandedRanges[i+1:]...)
+			break
+		}
+	}
+	return
+}
+
+// AddChart adds the chart to the sheet and returns it with the assigned ID.
+// The chart is overlaid at the top left of the sheet if no position is set,
+// and the anchor cell of an overlay position is set to the sheet.
+// If the chart is put on a new sheet, the spreadsheet is reloaded.
+func (s *Service) AddChart(sheet *Sheet, chart EmbeddedChart) (added EmbeddedChart, err error) {
+	chart.Position = sheet.embeddedObjectPosition(chart.Position)
+	if chart.Position.OverlayPosition != nil {
+		overlayPosition := *chart.Position.OverlayPosition
+		overlayPosition.AnchorCell.SheetID = sheet.Properties.ID
+		chart.Position.OverlayPosition = &overlayPosition
+	}
+	r, err := newUpdateRequest(sheet.Spreadsheet)
+	if err != nil {
+		return
+	}
+	res, err := r.AddChart(chart).DoWithResponse()
+	if err != nil {
+		return
+	}
+	if len(res.Replies) == 0 || res.Replies[0].AddChart == nil {
+		err = errors.New("no reply for the added chart")
+		return
+	}
+	added = res.Replies[0].AddChart.Chart
+	if chart.Position.NewSheet {
+		err = s.reloadSheet(sheet)
+		return
+	}
+	var stored EmbeddedChart
+	err = deepCopy(&stored, added)
+	if err != nil {
+		return
+	}
+	sheet.Charts = append(sheet.Charts, stored)
+	return
+}
+
+// UpdateChartSpec replaces the specification of the chart of the sheet
+func (s *Service) UpdateChartSpec(sheet *Sheet, chartID uint, spec ChartSpec) (err error) {
+	chart, err := sheet.ChartByID(chartID)
+	if err != nil {
+		return
+	}
+	r, err := newUpdateRequest(sheet.Spreadsheet)
+	if err != nil {
+		return
+	}
+	err = r.UpdateChartSpec(chartID, spec).Do()
+	if err != nil {
+		return
+	}
+	err = deepCopy(&chart.Spec, spec)
+	return
+}
+
+// UpdateEmbeddedObjectPosition moves the embedded object such as a chart of the sheet to the position.
+// The offsets and sizes of an overlay position which are zero are left as they are.
+// If the object is moved to another sheet or a new sheet, the spreadsheet is reloaded.
+func (s *Service) UpdateEmbeddedObjectPosition(sheet *Sheet, objectID uint, position EmbeddedObjectPosition) (err error) {
+	position = sheet.embeddedObjectPosition(position)
+	r, err := newUpdateRequest(sheet.Spreadsheet)
+	if err != nil {
+		return
+	}
+	err = r.UpdateEmbeddedObjectPosition(objectID, position).Do()
+	if err != nil {
+		return
+	}
+	chart, err := sheet.ChartByID(objectID)
+	if err != nil || position.OverlayPosition == nil || position.OverlayPosition.AnchorCell.SheetID != sheet.Properties.ID {
+		err = s.reloadSheet(sheet)
+		return
+	}
+	position.OverlayPosition = position.OverlayPosition.merge(chart.Position.OverlayPosition)
+	err = deepCopy(&chart.Position, position)
+	return
+}
+
+// DeleteEmbeddedObject deletes the embedded object such as a chart from the sheet
+func (s *Service) DeleteEmbeddedObject(sheet *Sheet, objectID uint) (err error) {
+	r, err := newUpdateRequest(sheet.Spreadsheet)
+	if err != nil {
+		return
+	}
+	err = r.DeleteEmbeddedObject(objectID).Do()
+	if err != nil {
+		return
+	}
+	for i, chart := range sheet.Charts {
+		if chart.ChartID == objectID {
+			sheet.Charts = append(sheet.Charts[:i], sheet.Charts[i+1:]...)
+			break
+		}
+	}
+	return
+}
+
+// CreateDeveloperMetadata creates the developer metadata and returns it with the assigned ID.
+// The spreadsheet is reloaded to reflect the metadata.
+func (s *Service) CreateDeveloperMetadata(spreadsheet *Spreadsheet, metadata DeveloperMetadata) (created DeveloperMetadata, err error) {
+	r, err := newUpdateRequest(spreadsheet)
+	if err != nil {
+		return
+	}
+	res, err := r.CreateDeveloperMetadata(metadata).DoWithResponse()
+	if err != nil {
+		return
+	}
+	if len(res.Replies) == 0 || res.Replies[0].CreateDeveloperMetadata == nil {
+		err = errors.New("no reply for the created developer metadata")
+		return
+	}
+	created = res.Replies[0].CreateDeveloperMetadata.DeveloperMetadata
+	err = s.ReloadSpreadsheet(spreadsheet)
+	return
+}
+
+// UpdateDeveloperMetadata updates the fields (e.g. "metadataValue,location") of
+// all developer metadata matching the filters, and returns the updated ones.
+// The spreadsheet is reloaded to reflect the metadata.
+func (s *Service) UpdateDeveloperMetadata(spreadsheet *Spreadsheet, filters []DataFilter, metadata DeveloperMetadata, fields string) (updated []DeveloperMetadata, err error) {
+	r, err := newUpdateRequest(spreadsheet)
+	if err != nil {
+		return
+	}
+	res, err := r.UpdateDeveloperMetadata(filters, metadata, fields).DoWithResponse()
+	if err != nil {
+		return
+	}
+	if len(res.Replies) > 0 && res.Replies[0].UpdateDeveloperMetadata != nil {
+		updated = res.Replies[0].UpdateDeveloperMetadata.DeveloperMetadata
+	}
+	err = s.ReloadSpreadsheet(spreadsheet)
+	return
+}
+
+// DeleteDeveloperMetadata deletes all developer metadata matching the filter, and returns the deleted ones.
+// The spreadsheet is reloaded to reflect the metadata.
+func (s *Service) DeleteDeveloperMetadata(spreadsheet *Spreadsheet, filter DataFilter) (deleted []DeveloperMetadata, err error) {
+	r, err := newUpdateRequest(spreadsheet)
+	if err != nil {
+		return
+	}
+	res, err := r.DeleteDeveloperMetadata(filter).DoWithResponse()
+	if err != nil {
+		return
+	}
+	if len(res.Replies) > 0 && res.Replies[0].DeleteDeveloperMetadata != nil {
+		deleted = res.Replies[0].DeleteDeveloperMetadata.DeletedDeveloperMetadata
+	}
+	err = s.ReloadSpreadsheet(spreadsheet)
+	return
+}
+
+// SearchDeveloperMetadata returns all developer metadata matching any of the filters.
+func (s *Service) SearchDeveloperMetadata(spreadsheet *Spreadsheet, filters ...DataFilter) (metadata []DeveloperMetadata, err error) {
+	path := fmt.Sprintf("/spreadsheets/%s/developerMetadata:search", spreadsheet.ID)
+	body, err := s.post(path, map[string]interface{}{
+		"dataFilters": filters,
+	})
+	if err != nil {
+		return
+	}
+	var res struct {
+		MatchedDeveloperMetadata []struct {
+			DeveloperMetadata DeveloperMetadata `json:"developerMetadata"`
+		} `json:"matchedDeveloperMetadata"`
+	}
+	err = json.Unmarshal([]byte(body), &res)
+	if err != nil {
+		return
+	}
+	metadata = make([]DeveloperMetadata, 0, len(res.MatchedDeveloperMetadata))
+	for _, matched := range res.MatchedDeveloperMetadata {
+		metadata = append(metadata, matched.DeveloperMetadata)
+	}
+	return
+}
+
+// ValuesByDataFilter returns the formatted values of the ranges matching any of the filters.
+// The values of each range are ordered by rows and then columns.
+func (s *Service) ValuesByDataFilter(spreadsheet *Spreadsheet, filters ...DataFilter) (valueRanges []ValueRange, err error) {
+	path := fmt.Sprintf("/spreadsheets/%s/values:batchGetByDataFilter", spreadsheet.ID)
+	body, err := s.post(path, map[string]interface{}{
+		"dataFilters":       filters,
+		"majorDimension":    DimensionRows,
+		"valueRenderOption": "FORMATTED_VALUE",
+	})
+	if err != nil {
+		return
+	}
+	var res struct {
+		ValueRanges []struct {
+			ValueRange ValueRange `json:"valueRange"`
+		} `json:"valueRanges"`
+	}
+	err = json.Unmarshal([]byte(body), &res)
+	if err != nil {
+		return
+	}
+	valueRanges = make([]ValueRange, 0, len(res.ValueRanges))
+	for _, matched := range res.ValueRanges {
+		valueRanges = append(valueRanges, matched.ValueRange)
+	}
+	return
+}
+
+// SyncSheet updates sheet
+func (s *Service) SyncSheet(sheet *Sheet) (err error) {
+	if sheet.newMaxRow > sheet.Properties.GridProperties.RowCount ||
+		sheet.newMaxColumn > sheet.Properties.GridProperties.ColumnCount {
+		err = s.ExpandSheet(sheet, sheet.newMaxRow, sheet.newMaxColumn)
+		if err != nil {
+			return
+		}
+	}
+	r, err := newUpdateRequest(sheet.Spreadsheet)
+	if err != nil {
+		return
+	}
+	err = r.UpdateCells(sheet).Do()
+	if err != nil {
+		return
+	}
+	sheet.modifiedCells = []*Cell{}
+	sheet.Properties.GridProperties.RowCount = sheet.newMaxRow
+	sheet.Properties.GridProperties.ColumnCount = sheet.newMaxColumn
+	return
+}
+
+// UpdateSheetProperties updates the properties of the sheet.
+// Only the fields which differ from the current properties are sent.
+func (s *Service) UpdateSheetProperties(sheet *Sheet, properties SheetProperties) (err error) {
+	r, err := newUpdateRequest(sheet.Spreadsheet)
+	if err != nil {
+		return
+	}
+	r.UpdateSheetProperties(sheet, &properties)
+	if len(r.body["requests"]) == 0 {
+		return
+	}
+	err = r.Do()
+	if err != nil {
+		return
+	}
+	properties.ID = sheet.Properties.ID
+	properties.SheetType = sheet.Properties.SheetType
+	if properties.Index != sheet.Properties.Index {
+		from, to := sheet.Properties.Index, properties.Index
+		for i := range sheet.Spreadsheet.Sheets {
+			other := &sheet.Spreadsheet.Sheets[i].Properties
+			if other.ID == sheet.Properties.ID {
+				continue
+			}
+			if from < to && other.Index > from && other.Index <= to {
+				other.Index--
+			} else if to < from && other.Index >= to && other.Index < from {
+				other.Index++
+			}
+		}
+	}
+	if properties.GridProperties.RowCount != sheet.Properties.GridProperties.RowCount {
+		sheet.newMaxRow = properties.GridProperties.RowCount
+	}
+	if properties.GridProperties.ColumnCount != sheet.Properties.GridProperties.ColumnCount {
+		sheet.newMaxColumn = properties.GridProperties.ColumnCount
+	}
+	sheet.Properties = properties
+	return
+}
+
+// ExpandSheet expands the range of the sheet
+func (s *Service) ExpandSheet(sheet *Sheet, row, column uint) (err error) {
+	props := sheet.Properties
+	props.GridProperties.RowCount = row
+	props.GridProperties.ColumnCount = column
+
+	r, err := newUpdateRequest(sheet.Spreadsheet)
+	if err != nil {
+		return
+	}
+	err = r.UpdateSheetProperties(sheet, &props).Do()
+	if err != nil {
+		return
+	}
+	sheet.newMaxRow = row
+	sheet.newMaxColumn = column
+	return
+}
+
+// DeleteRows deletes rows from the sheet
+func (s *Service) DeleteRows(sheet *Sheet, start, end int) (err error) {
+	sheet.Properties.GridProperties.RowCount -= uint(end - start)
+	sheet.newMaxRow -= uint(end - start)
+	r, err := newUpdateRequest(sheet.Spreadsheet)
+	if err != nil {
+		return
+	}
+	err = r.DeleteDimension(sheet, DimensionRows, start, end).Do()
+	return
+}
+
+// DeleteColumns deletes columns from the sheet
+func (s *Service) DeleteColumns(sheet *Sheet, start, end int) (err error) {
+	sheet.Properties.GridProperties.ColumnCount -= uint(end - start)
+	sheet.newMaxRow -= uint(end - start)
+	r, err := newUpdateRequest(sheet.Spreadsheet)
+	if err != nil {
+		return
+	}
+	err = r.DeleteDimension(sheet, DimensionColumns, start, end).Do()
+	return
+}
+
+// UpdateDimensionProperties updates the fields (e.g. "pixelSize,hiddenByUser")
+// of the rows or columns from start to end of the sheet.
+// The fields are pixelSize, hiddenByUser and developerMetadata, and others are an error.
+func (s *Service) UpdateDimensionProperties(sheet *Sheet, dimension Dimension, start, end int, properties DimensionProperties, fields string) (err error) {
+	for _, field := range strings.Split(fields, ",") {
+		switch field {
+		case "pixelSize", "hiddenByUser", "developerMetadata":
+		default:
+			err = fmt.Errorf("unsupported dimension property field %q", field)
+			return
+		}
+	}
+	r, err := newUpdateRequest(sheet.Spreadsheet)
+	if err != nil {
+		return
+	}
+	dimensionRange := DimensionRange{
+		SheetID:    sheet.Properties.ID,
+		Dimension:  dimension,
+		StartIndex: uint(start),
+		EndIndex:   uint(end),
+	}
+	err = r.UpdateDimensionProperties(dimensionRange, properties, fields).Do()
+	if err != nil {
+		return
+	}
+	sheet.updateDimensionMetadata(dimension, start, end, func(p *DimensionProperties) {
+		for _, field := range strings.Split(fields, ",") {
+			switch field {
+			case "pixelSize":
+				p.PixelSize = properties.PixelSize
+			case "hiddenByUser":
+				p.HiddenByUser = properties.HiddenByUser
+			case "developerMetadata":
+				if copyErr := deepCopy(&p.DeveloperMetadata, properties.DeveloperMetadata); copyErr != nil {
+					err = copyErr
+				}
+			}
+		}
+	})
+	return
+}
+
+// AutoResizeDimensions resizes the rows or columns from start to end of the sheet to fit their contents.
+func (s *Service) AutoResizeDimensions(sheet *Sheet, dimension Dimension, start, end int) (err error) {
+	r, err := newUpdateRequest(sheet.Spreadsheet)
+	if err != nil {
+		return
+	}
+	dimensionRange := DimensionRange{
+		SheetID:    sheet.Properties.ID,
+		Dimension:  dimension,
+		StartIndex: uint(start),
+		EndIndex:   uint(end),
+	}
+	err = r.AutoResizeDimensions(dimensionRange).Do()
+	if err != nil {
+		return
+	}
+	err = s.reloadSheet(sheet)
 	return
 }
 
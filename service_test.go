@@ -1,6 +1,8 @@
 package spreadsheet
 
 import (
+	"bytes"
+	"strings"
 	"testing"
 	"time"
 
@@ -88,6 +90,8 @@ func (suite *TestSuite) TestFetchSpreadsheet() {
 			}
 		}
 	}
+	suite.True(sheet.RowMetadata(4).HiddenByUser)
+	suite.NotZero(sheet.ColumnMetadata(0).PixelSize)
 
 	// Test SheetByID
 	sheet2, err := spreadsheet.SheetByID(TestSheet2ID)
@@ -104,6 +108,29 @@ func (suite *TestSuite) TestFetchSpreadsheetWithCache() {
 	suite.True(spreadsheet2.cached)
 }
 
+func (suite *TestSuite) TestUpdateSpreadsheetProperties() {
+	spreadsheet, err := suite.service.CreateSpreadsheet(Spreadsheet{
+		Properties: Properties{
+			Title: "testspreadsheet",
+		},
+	})
+	suite.Require().NoError(err)
+
+	properties := spreadsheet.Properties
+	properties.Title = "renamed testspreadsheet"
+	properties.TimeZone = "Asia/Tokyo"
+	properties.IterativeCalculationSettings = &IterativeCalculationSettings{MaxIterations: 10, ConvergenceThreshold: 0.01}
+	err = suite.service.UpdateSpreadsheetProperties(&spreadsheet, properties)
+	suite.Require().NoError(err)
+
+	err = suite.service.ReloadSpreadsheet(&spreadsheet)
+	suite.Require().NoError(err)
+	suite.Equal("renamed testspreadsheet", spreadsheet.Properties.Title)
+	suite.Equal("Asia/Tokyo", spreadsheet.Properties.TimeZone)
+	suite.Require().NotNil(spreadsheet.Properties.IterativeCalculationSettings)
+	suite.Equal(10, spreadsheet.Properties.IterativeCalculationSettings.MaxIterations)
+}
+
 func (suite *TestSuite) TestAdd_DeleteSheet() {
 	spreadsheet, err := suite.service.FetchSpreadsheet(spreadsheetID)
 	suite.Require().NoError(err)
@@ -120,6 +147,81 @@ func (suite *TestSuite) TestAdd_DeleteSheet() {
 	suite.Require().NoError(err)
 }
 
+func (suite *TestSuite) TestUpdateSheetProperties() {
+	spreadsheet, err := suite.service.CreateSpreadsheet(Spreadsheet{
+		Properties: Properties{
+			Title: "testspreadsheet",
+		},
+		Sheets: []Sheet{
+			{Properties: SheetProperties{Title: "sheet 1"}},
+			{Properties: SheetProperties{Title: "sheet 2"}},
+			{Properties: SheetProperties{Title: "sheet 3"}},
+		},
+	})
+	suite.Require().NoError(err)
+	sheet, err := spreadsheet.SheetByTitle("sheet 1")
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(sheet.Rename("renamed"))
+	suite.Require().NoError(sheet.MoveTo(2))
+	suite.Require().NoError(sheet.Hide())
+	suite.Require().NoError(sheet.SetTabColor(TabColor{Red: 1}))
+	suite.Require().NoError(sheet.Freeze(1, 2))
+	suite.Require().NoError(sheet.SetRightToLeft(true))
+	suite.Equal(uint(2), sheet.Properties.Index)
+	other, err := spreadsheet.SheetByIndex(0)
+	suite.Require().NoError(err)
+	suite.Equal("sheet 2", other.Properties.Title)
+
+	err = suite.service.ReloadSpreadsheet(&spreadsheet)
+	suite.Require().NoError(err)
+	sheet, err = spreadsheet.SheetByTitle("renamed")
+	suite.Require().NoError(err)
+	suite.Equal(uint(2), sheet.Properties.Index)
+	suite.True(sheet.Properties.Hidden)
+	suite.Equal(float32(1), sheet.Properties.TabColor.Red)
+	suite.Equal(uint(1), sheet.Properties.GridProperties.FrozenRowCount)
+	suite.Equal(uint(2), sheet.Properties.GridProperties.FrozenColumnCount)
+	suite.True(sheet.Properties.RightToLeft)
+	other, err = spreadsheet.SheetByIndex(1)
+	suite.Require().NoError(err)
+	suite.Equal("sheet 3", other.Properties.Title)
+
+	suite.Require().NoError(sheet.Show())
+	suite.Require().NoError(sheet.MoveTo(0))
+	err = suite.service.ReloadSpreadsheet(&spreadsheet)
+	suite.Require().NoError(err)
+	sheet, err = spreadsheet.SheetByIndex(0)
+	suite.Require().NoError(err)
+	suite.Equal("renamed", sheet.Properties.Title)
+	suite.False(sheet.Properties.Hidden)
+}
+
+func (suite *TestSuite) TestFormulaErrors() {
+	spreadsheet, err := suite.service.CreateSpreadsheet(Spreadsheet{
+		Properties: Properties{
+			Title: "testspreadsheet",
+		},
+	})
+	suite.Require().NoError(err)
+	sheet, err := spreadsheet.SheetByIndex(0)
+	suite.Require().NoError(err)
+	sheet.Update(0, 0, "=1/0")
+	sheet.Update(1, 0, "=NA()")
+	suite.Require().NoError(sheet.Synchronize())
+
+	_, err = suite.service.FetchSpreadsheet(spreadsheet.ID)
+	suite.Require().NoError(err)
+	_, err = suite.service.FetchSpreadsheet(spreadsheet.ID, WithFormulaErrors())
+	suite.Require().Error(err)
+	errs, ok := err.(FormulaErrors)
+	suite.Require().True(ok)
+	suite.Equal(2, len(errs))
+	suite.Equal(ErrorTypeDivideByZero, errs[0].Value().Type)
+	suite.Equal("A2", errs[1].Cell.Pos())
+	suite.Equal(ErrorTypeNA, errs[1].Value().Type)
+}
+
 func (suite *TestSuite) TestSyncSheet() {
 	spreadsheet, err := suite.service.FetchSpreadsheet(spreadsheetID)
 	suite.Require().NoError(err)
@@ -142,6 +244,629 @@ func (suite *TestSuite) TestDeleteRows() {
 	suite.Equal(rowCount-1, sheet.Properties.GridProperties.RowCount)
 }
 
+func (suite *TestSuite) TestCopyPaste() {
+	spreadsheet, err := suite.service.FetchSpreadsheet(spreadsheetID)
+	suite.Require().NoError(err)
+	source := GridRange{
+		SheetID:          0,
+		StartRowIndex:    0,
+		EndRowIndex:      2,
+		StartColumnIndex: 0,
+		EndColumnIndex:   2,
+	}
+	destination := GridRange{
+		SheetID:          TestSheet2ID,
+		StartRowIndex:    10,
+		EndRowIndex:      12,
+		StartColumnIndex: 0,
+		EndColumnIndex:   2,
+	}
+	err = suite.service.CopyPaste(&spreadsheet, source, destination, PasteNormal, PasteOrientationNormal)
+	suite.Require().NoError(err)
+
+	sheet, err := spreadsheet.SheetByID(0)
+	suite.Require().NoError(err)
+	sheet2, err := spreadsheet.SheetByID(TestSheet2ID)
+	suite.Require().NoError(err)
+	suite.Equal(sheet.Rows[0][0].Value, sheet2.Rows[10][0].Value)
+
+	err = suite.service.CutPaste(&spreadsheet, destination, GridCoordinate{
+		SheetID:     TestSheet2ID,
+		RowIndex:    20,
+		ColumnIndex: 0,
+	}, PasteValues)
+	suite.NoError(err)
+}
+
+func (suite *TestSuite) TestPasteData() {
+	spreadsheet, err := suite.service.FetchSpreadsheet(spreadsheetID)
+	suite.Require().NoError(err)
+	sheet, err := spreadsheet.SheetByTitle("TestSheet2")
+	suite.Require().NoError(err)
+
+	err = sheet.PasteData(30, 0, "a;b\nc;d", ";")
+	suite.Require().NoError(err)
+	suite.Equal("a", sheet.Rows[30][0].Value)
+	suite.Equal("d", sheet.Rows[31][1].Value)
+
+	err = sheet.PasteHTML(30, 0, "<table><tr><td>e</td><td>f</td></tr></table>")
+	suite.Require().NoError(err)
+	suite.Equal("f", sheet.Rows[30][1].Value)
+}
+
+func (suite *TestSuite) TestTextToColumns() {
+	spreadsheet, err := suite.service.FetchSpreadsheet(spreadsheetID)
+	suite.Require().NoError(err)
+	sheet, err := spreadsheet.SheetByTitle("TestSheet2")
+	suite.Require().NoError(err)
+	sheet.Update(40, 0, "Last, First")
+	err = sheet.Synchronize()
+	suite.Require().NoError(err)
+
+	err = sheet.TextToColumns(GridRange{
+		StartRowIndex:    40,
+		EndRowIndex:      41,
+		StartColumnIndex: 0,
+		EndColumnIndex:   1,
+	}, DelimiterComma, "")
+	suite.Require().NoError(err)
+	suite.Equal("Last", sheet.Rows[40][0].Value)
+
+	err = sheet.TextToColumns(GridRange{}, DelimiterCustom, "")
+	suite.Error(err)
+}
+
+func (suite *TestSuite) TestAutoFill() {
+	spreadsheet, err := suite.service.FetchSpreadsheet(spreadsheetID)
+	suite.Require().NoError(err)
+	sheet, err := spreadsheet.SheetByTitle("TestSheet2")
+	suite.Require().NoError(err)
+	sheet.Update(50, 0, "1")
+	sheet.Update(51, 0, "2")
+	err = sheet.Synchronize()
+	suite.Require().NoError(err)
+
+	err = sheet.AutoFill(GridRange{
+		StartRowIndex:    50,
+		EndRowIndex:      55,
+		StartColumnIndex: 0,
+		EndColumnIndex:   1,
+	}, false)
+	suite.Require().NoError(err)
+	suite.Equal("5", sheet.Rows[54][0].Value)
+
+	err = sheet.AutoFillSourceAndDestination(SourceAndDestination{
+		Source: GridRange{
+			StartRowIndex:    50,
+			EndRowIndex:      52,
+			StartColumnIndex: 0,
+			EndColumnIndex:   1,
+		},
+		Dimension:  DimensionRows,
+		FillLength: 5,
+	}, false)
+	suite.Require().NoError(err)
+	suite.Equal("7", sheet.Rows[56][0].Value)
+}
+
+func (suite *TestSuite) TestUpdateDimensionProperties() {
+	spreadsheet, err := suite.service.FetchSpreadsheet(spreadsheetID)
+	suite.Require().NoError(err)
+	sheet, err := spreadsheet.SheetByTitle("TestSheet2")
+	suite.Require().NoError(err)
+
+	err = sheet.SetColumnWidth(0, 2, 150)
+	suite.Require().NoError(err)
+	suite.Equal(uint(150), sheet.ColumnMetadata(1).PixelSize)
+
+	err = sheet.HideRows(1, 2)
+	suite.Require().NoError(err)
+	suite.True(sheet.RowMetadata(1).HiddenByUser)
+	err = sheet.ShowRows(1, 2)
+	suite.Require().NoError(err)
+	suite.False(sheet.RowMetadata(1).HiddenByUser)
+
+	err = sheet.AutoResizeColumns(0, 2)
+	suite.NoError(err)
+}
+
+func (suite *TestSuite) TestNamedRange() {
+	spreadsheet, err := suite.service.FetchSpreadsheet(spreadsheetID)
+	suite.Require().NoError(err)
+	namedRange, err := suite.service.AddNamedRange(&spreadsheet, "TestNamedRange", GridRange{
+		SheetID:          TestSheet2ID,
+		StartRowIndex:    0,
+		EndRowIndex:      2,
+		StartColumnIndex: 0,
+		EndColumnIndex:   2,
+	})
+	suite.Require().NoError(err)
+	suite.NotEmpty(namedRange.NamedRangeID)
+
+	sheet, cells, err := spreadsheet.RangeByName("TestNamedRange")
+	suite.Require().NoError(err)
+	suite.Equal(uint(TestSheet2ID), sheet.Properties.ID)
+	suite.Equal(2, len(cells))
+
+	namedRange.Name = "TestRenamedRange"
+	err = suite.service.UpdateNamedRange(&spreadsheet, namedRange)
+	suite.Require().NoError(err)
+	_, err = spreadsheet.NamedRangeByName("TestRenamedRange")
+	suite.Require().NoError(err)
+
+	err = suite.service.DeleteNamedRange(&spreadsheet, namedRange.NamedRangeID)
+	suite.Require().NoError(err)
+	_, err = spreadsheet.NamedRangeByID(namedRange.NamedRangeID)
+	suite.Error(err)
+}
+
+func (suite *TestSuite) TestConditionalFormatRule() {
+	spreadsheet, err := suite.service.FetchSpreadsheet(spreadsheetID)
+	suite.Require().NoError(err)
+	sheet, err := spreadsheet.SheetByTitle("TestSheet2")
+	suite.Require().NoError(err)
+	count := len(sheet.ConditionalFormats)
+
+	err = suite.service.AddConditionalFormatRule(sheet, ConditionalFormatRule{
+		Ranges: []GridRange{{EndRowIndex: 10, EndColumnIndex: 1}},
+		BooleanRule: &BooleanRule{
+			Condition: BooleanCondition{
+				Type:   ConditionNumberGreater,
+				Values: []ConditionValue{{UserEnteredValue: "10"}},
+			},
+			Format: CellFormat{
+				BackgroundColor: &Color{Red: 1},
+			},
+		},
+	}, 0)
+	suite.Require().NoError(err)
+	suite.Equal(count+1, len(sheet.ConditionalFormats))
+
+	err = suite.service.UpdateConditionalFormatRule(sheet, 0, ConditionalFormatRule{
+		Ranges: []GridRange{{EndRowIndex: 10, EndColumnIndex: 1}},
+		GradientRule: &GradientRule{
+			Minpoint: InterpolationPoint{Color: Color{Red: 1}, Type: InterpolationPointMin},
+			Maxpoint: InterpolationPoint{Color: Color{Green: 1}, Type: InterpolationPointMax},
+		},
+	})
+	suite.Require().NoError(err)
+
+	err = suite.service.DeleteConditionalFormatRule(sheet, 0)
+	suite.Require().NoError(err)
+	suite.Equal(count, len(sheet.ConditionalFormats))
+
+	err = suite.service.ReloadSpreadsheet(&spreadsheet)
+	suite.Require().NoError(err)
+	sheet, err = spreadsheet.SheetByTitle("TestSheet2")
+	suite.Require().NoError(err)
+	suite.Equal(count, len(sheet.ConditionalFormats))
+}
+
+func (suite *TestSuite) TestSetDataValidation() {
+	spreadsheet, err := suite.service.FetchSpreadsheet(spreadsheetID)
+	suite.Require().NoError(err)
+	sheet, err := spreadsheet.SheetByTitle("TestSheet2")
+	suite.Require().NoError(err)
+
+	sheet.Update(0, 5, "no")
+	suite.Require().NoError(sheet.Synchronize())
+
+	gridRange := GridRange{StartRowIndex: 0, EndRowIndex: 2, StartColumnIndex: 5, EndColumnIndex: 6}
+	err = sheet.SetDataValidation(gridRange, &DataValidationRule{
+		Condition: BooleanCondition{
+			Type:   ConditionOneOfList,
+			Values: []ConditionValue{{UserEnteredValue: "yes"}, {UserEnteredValue: "no"}},
+		},
+		Strict:       true,
+		ShowCustomUI: true,
+	})
+	suite.Require().NoError(err)
+	sheet.Update(0, 5, "maybe")
+	suite.Error(sheet.Validate())
+	sheet.Update(0, 5, "yes")
+	suite.NoError(sheet.Validate())
+	suite.Require().NoError(sheet.Synchronize())
+
+	err = suite.service.ReloadSpreadsheet(&spreadsheet)
+	suite.Require().NoError(err)
+	sheet, err = spreadsheet.SheetByTitle("TestSheet2")
+	suite.Require().NoError(err)
+	suite.Require().NotNil(sheet.Rows[0][5].DataValidation())
+	suite.Equal(ConditionOneOfList, sheet.Rows[0][5].DataValidation().Condition.Type)
+
+	err = sheet.SetDataValidation(gridRange, nil)
+	suite.Require().NoError(err)
+	suite.Nil(sheet.Rows[0][5].DataValidation())
+}
+
+func (suite *TestSuite) TestProtectedRange() {
+	spreadsheet, err := suite.service.FetchSpreadsheet(spreadsheetID)
+	suite.Require().NoError(err)
+	sheet, err := spreadsheet.SheetByTitle("TestSheet2")
+	suite.Require().NoError(err)
+
+	protectedRange, err := suite.service.AddProtectedRange(sheet, ProtectedRange{
+		Range:       &GridRange{StartColumnIndex: 3, EndColumnIndex: 4},
+		Description: "formulas",
+		WarningOnly: true,
+	})
+	suite.Require().NoError(err)
+	suite.NotZero(protectedRange.ProtectedRangeID)
+	current, err := sheet.ProtectedRangeByID(protectedRange.ProtectedRangeID)
+	suite.Require().NoError(err)
+	suite.NotSame(protectedRange.Range, current.Range)
+
+	// edit the returned range in place, which must not change the stored one
+	protectedRange.Range.EndColumnIndex = 5
+	protectedRange.Description = "locked formulas"
+	err = suite.service.UpdateProtectedRange(sheet, protectedRange)
+	suite.Require().NoError(err)
+	current, err = sheet.ProtectedRangeByID(protectedRange.ProtectedRangeID)
+	suite.Require().NoError(err)
+	suite.Equal("locked formulas", current.Description)
+	suite.NotSame(protectedRange.Range, current.Range)
+
+	err = suite.service.ReloadSpreadsheet(&spreadsheet)
+	suite.Require().NoError(err)
+	sheet, err = spreadsheet.SheetByTitle("TestSheet2")
+	suite.Require().NoError(err)
+	current, err = sheet.ProtectedRangeByID(protectedRange.ProtectedRangeID)
+	suite.Require().NoError(err)
+	suite.Equal(uint(5), current.Range.EndColumnIndex)
+
+	err = suite.service.DeleteProtectedRange(sheet, protectedRange.ProtectedRangeID)
+	suite.Require().NoError(err)
+	_, err = sheet.ProtectedRangeByID(protectedRange.ProtectedRangeID)
+	suite.Error(err)
+}
+
+func (suite *TestSuite) TestBasicFilter() {
+	spreadsheet, err := suite.service.FetchSpreadsheet(spreadsheetID)
+	suite.Require().NoError(err)
+	sheet, err := spreadsheet.SheetByTitle("TestSheet2")
+	suite.Require().NoError(err)
+	sheet.Update(60, 0, "show")
+	sheet.Update(61, 0, "hide")
+	suite.Require().NoError(sheet.Synchronize())
+
+	err = sheet.SetBasicFilter(BasicFilter{
+		Range: GridRange{StartRowIndex: 59, EndRowIndex: 62, StartColumnIndex: 0, EndColumnIndex: 1},
+		Criteria: map[string]FilterCriteria{
+			"0": {HiddenValues: []string{"hide"}},
+		},
+	})
+	suite.Require().NoError(err)
+	suite.Require().NotNil(sheet.BasicFilter)
+	suite.Equal([]uint{61}, sheet.RowsHiddenByFilter())
+
+	err = sheet.ClearBasicFilter()
+	suite.Require().NoError(err)
+	suite.Nil(sheet.BasicFilter)
+	suite.Empty(sheet.RowsHiddenByFilter())
+}
+
+func (suite *TestSuite) TestFilterView() {
+	spreadsheet, err := suite.service.FetchSpreadsheet(spreadsheetID)
+	suite.Require().NoError(err)
+	sheet, err := spreadsheet.SheetByTitle("TestSheet2")
+	suite.Require().NoError(err)
+
+	filterView, err := suite.service.AddFilterView(sheet, FilterView{
+		Title:     "team view",
+		Range:     &GridRange{EndColumnIndex: 2},
+		SortSpecs: []SortSpec{{DimensionIndex: 0, SortOrder: SortOrderAscending}},
+	})
+	suite.Require().NoError(err)
+	suite.NotZero(filterView.FilterViewID)
+	stored, err := sheet.FilterViewByID(filterView.FilterViewID)
+	suite.Require().NoError(err)
+	suite.NotSame(filterView.Range, stored.Range)
+
+	// edit the returned range in place, which must not change the stored one
+	filterView.Range.EndColumnIndex = 3
+	filterView.Title = "renamed view"
+	err = suite.service.UpdateFilterView(sheet, filterView)
+	suite.Require().NoError(err)
+	stored, err = sheet.FilterViewByID(filterView.FilterViewID)
+	suite.Require().NoError(err)
+	suite.NotSame(filterView.Range, stored.Range)
+
+	duplicated, err := suite.service.DuplicateFilterView(sheet, filterView.FilterViewID)
+	suite.Require().NoError(err)
+	suite.NotEqual(filterView.FilterViewID, duplicated.FilterViewID)
+	stored, err = sheet.FilterViewByID(duplicated.FilterViewID)
+	suite.Require().NoError(err)
+	suite.NotSame(duplicated.Range, stored.Range)
+
+	err = suite.service.ReloadSpreadsheet(&spreadsheet)
+	suite.Require().NoError(err)
+	sheet, err = spreadsheet.SheetByTitle("TestSheet2")
+	suite.Require().NoError(err)
+	current, err := sheet.FilterViewByID(filterView.FilterViewID)
+	suite.Require().NoError(err)
+	suite.Equal("renamed view", current.Title)
+	suite.Equal(uint(3), current.Range.EndColumnIndex)
+
+	suite.Require().NoError(suite.service.DeleteFilterView(sheet, filterView.FilterViewID))
+	suite.Require().NoError(suite.service.DeleteFilterView(sheet, duplicated.FilterViewID))
+	_, err = sheet.FilterViewByID(filterView.FilterViewID)
+	suite.Error(err)
+}
+
+func (suite *TestSuite) TestBanding() {
+	spreadsheet, err := suite.service.FetchSpreadsheet(spreadsheetID)
+	suite.Require().NoError(err)
+	sheet, err := spreadsheet.SheetByTitle("TestSheet2")
+	suite.Require().NoError(err)
+
+	bandedRange, err := suite.service.AddBanding(sheet, BandedRange{
+		Range: GridRange{StartRowIndex: 70, EndRowIndex: 80, EndColumnIndex: 3},
+		RowProperties: &BandingProperties{
+			HeaderColor:     &Color{Blue: 1},
+			FirstBandColor:  &Color{Red: 1, Green: 1, Blue: 1},
+			SecondBandColor: &Color{Red: 0.9, Green: 0.9, Blue: 0.9},
+		},
+	})
+	suite.Require().NoError(err)
+	suite.NotZero(bandedRange.BandedRangeID)
+
+	bandedRange.RowProperties.FooterColor = &Color{Green: 1}
+	err = suite.service.UpdateBanding(sheet, bandedRange)
+	suite.Require().NoError(err)
+
+	err = suite.service.ReloadSpreadsheet(&spreadsheet)
+	suite.Require().NoError(err)
+	sheet, err = spreadsheet.SheetByTitle("TestSheet2")
+	suite.Require().NoError(err)
+	current, err := sheet.BandedRangeByID(bandedRange.BandedRangeID)
+	suite.Require().NoError(err)
+	suite.NotNil(current.RowProperties.FooterColor)
+
+	err = suite.service.DeleteBanding(sheet, bandedRange.BandedRangeID)
+	suite.Require().NoError(err)
+	_, err = sheet.BandedRangeByID(bandedRange.BandedRangeID)
+	suite.Error(err)
+}
+
+func (suite *TestSuite) TestChart() {
+	spreadsheet, err := suite.service.FetchSpreadsheet(spreadsheetID)
+	suite.Require().NoError(err)
+	sheet, err := spreadsheet.SheetByTitle("TestSheet2")
+	suite.Require().NoError(err)
+
+	chart, err := suite.service.AddChart(sheet, EmbeddedChart{
+		Spec: ChartSpec{
+			Title: "weekly metrics",
+			BasicChart: &BasicChartSpec{
+				ChartType: BasicChartLine,
+				Domains: []BasicChartDomain{{
+					Domain: ChartData{SourceRange: ChartSourceRange{Sources: []GridRange{
+						{SheetID: TestSheet2ID, EndRowIndex: 10, StartColumnIndex: 0, EndColumnIndex: 1},
+					}}},
+				}},
+				Series: []BasicChartSeries{{
+					Series: ChartData{SourceRange: ChartSourceRange{Sources: []GridRange{
+						{SheetID: TestSheet2ID, EndRowIndex: 10, StartColumnIndex: 1, EndColumnIndex: 2},
+					}}},
+					TargetAxis: "LEFT_AXIS",
+				}},
+				HeaderCount: 1,
+			},
+		},
+	})
+	suite.Require().NoError(err)
+	suite.NotZero(chart.ChartID)
+
+	chart.Spec.Title = "monthly metrics"
+	chart.Spec.BasicChart.ChartType = BasicChartColumn
+	err = suite.service.UpdateChartSpec(sheet, chart.ChartID, chart.Spec)
+	suite.Require().NoError(err)
+
+	err = suite.service.UpdateEmbeddedObjectPosition(sheet, chart.ChartID, EmbeddedObjectPosition{
+		OverlayPosition: &OverlayPosition{
+			AnchorCell: GridCoordinate{SheetID: TestSheet2ID, RowIndex: 5, ColumnIndex: 5},
+		},
+	})
+	suite.Require().NoError(err)
+	current, err := sheet.ChartByID(chart.ChartID)
+	suite.Require().NoError(err)
+	suite.Equal(uint(5), current.Position.OverlayPosition.AnchorCell.RowIndex)
+
+	err = suite.service.DeleteEmbeddedObject(sheet, chart.ChartID)
+	suite.Require().NoError(err)
+	_, err = sheet.ChartByID(chart.ChartID)
+	suite.Error(err)
+}
+
+func (suite *TestSuite) TestPivotTable() {
+	spreadsheet, err := suite.service.FetchSpreadsheet(spreadsheetID)
+	suite.Require().NoError(err)
+	sheet, err := spreadsheet.SheetByTitle("TestSheet2")
+	suite.Require().NoError(err)
+
+	sheet.UpdatePivotTable(0, 10, &PivotTable{
+		Source: GridRange{SheetID: 0, EndRowIndex: 3, EndColumnIndex: 3},
+		Rows: []PivotGroup{
+			{SourceColumnOffset: 0, ShowTotals: true, SortOrder: SortOrderAscending},
+		},
+		Values: []PivotValue{
+			{SourceColumnOffset: 1, SummarizeFunction: SummarizeCountA},
+		},
+	})
+	suite.Require().NoError(sheet.Synchronize())
+
+	err = suite.service.ReloadSpreadsheet(&spreadsheet)
+	suite.Require().NoError(err)
+	sheet, err = spreadsheet.SheetByTitle("TestSheet2")
+	suite.Require().NoError(err)
+	pivotTable := sheet.Rows[0][10].PivotTable()
+	suite.Require().NotNil(pivotTable)
+	suite.Equal(SummarizeCountA, pivotTable.Values[0].SummarizeFunction)
+
+	sheet.UpdatePivotTable(0, 10, nil)
+	suite.NoError(sheet.Synchronize())
+}
+
+func (suite *TestSuite) TestUpdateLink() {
+	spreadsheet, err := suite.service.FetchSpreadsheet(spreadsheetID)
+	suite.Require().NoError(err)
+	sheet, err := spreadsheet.SheetByTitle("TestSheet2")
+	suite.Require().NoError(err)
+
+	sheet.UpdateLink(90, 0, "example", "https://example.com/")
+	sheet.UpdateTextLink(90, 1, "example", "https://example.com/")
+	suite.Require().NoError(sheet.Synchronize())
+
+	err = suite.service.ReloadSpreadsheet(&spreadsheet)
+	suite.Require().NoError(err)
+	sheet, err = spreadsheet.SheetByTitle("TestSheet2")
+	suite.Require().NoError(err)
+	suite.Equal("https://example.com/", sheet.Rows[90][0].Hyperlink())
+	suite.NotEmpty(sheet.Links())
+}
+
+func (suite *TestSuite) TestUpdateTime() {
+	spreadsheet, err := suite.service.FetchSpreadsheet(spreadsheetID)
+	suite.Require().NoError(err)
+	sheet, err := spreadsheet.SheetByTitle("TestSheet2")
+	suite.Require().NoError(err)
+	loc, err := spreadsheet.Location()
+	suite.Require().NoError(err)
+
+	tm := time.Date(2020, 1, 2, 3, 4, 5, 0, loc)
+	suite.Require().NoError(sheet.UpdateTime(95, 0, tm, NumberFormatDateTime))
+	suite.Require().NoError(sheet.UpdateTime(95, 1, tm, NumberFormatDate))
+	sheet.UpdateDuration(95, 2, 25*time.Hour)
+	suite.Require().NoError(sheet.Synchronize())
+
+	err = suite.service.ReloadSpreadsheet(&spreadsheet)
+	suite.Require().NoError(err)
+	sheet, err = spreadsheet.SheetByTitle("TestSheet2")
+	suite.Require().NoError(err)
+	got, err := sheet.Rows[95][0].Time()
+	suite.Require().NoError(err)
+	suite.True(tm.Equal(got))
+	suite.Equal(NumberFormatDate, sheet.Rows[95][1].UserEnteredFormat().NumberFormat.Type)
+	d, err := sheet.Rows[95][2].Duration()
+	suite.Require().NoError(err)
+	suite.Equal(25*time.Hour, d)
+}
+
+func (suite *TestSuite) TestCSV() {
+	spreadsheet, err := suite.service.FetchSpreadsheet(spreadsheetID)
+	suite.Require().NoError(err)
+	sheet, err := spreadsheet.SheetByTitle("TestSheet2")
+	suite.Require().NoError(err)
+
+	data := "name,count\na,1\nb,=B107+1\n"
+	err = sheet.ReadCSV(strings.NewReader(data), GridRange{StartRowIndex: 105, EndRowIndex: 108, EndColumnIndex: 2}, CSVOptions{})
+	suite.Require().NoError(err)
+
+	err = suite.service.ReloadSpreadsheet(&spreadsheet)
+	suite.Require().NoError(err)
+	sheet, err = spreadsheet.SheetByTitle("TestSheet2")
+	suite.Require().NoError(err)
+	n, ok := sheet.Rows[107][1].Int()
+	suite.True(ok)
+	suite.Equal(2, n)
+
+	var buf bytes.Buffer
+	suite.Require().NoError(sheet.WriteCSV(&buf, CSVOptions{Value: CSVRawValue, Trim: true}))
+	suite.Contains(buf.String(), "b,=B107+1")
+}
+
+func (suite *TestSuite) TestXLSX() {
+	spreadsheet, err := suite.service.FetchSpreadsheet(spreadsheetID)
+	suite.Require().NoError(err)
+	var buf bytes.Buffer
+	suite.Require().NoError(spreadsheet.WriteXLSX(&buf))
+
+	imported, err := ReadXLSX(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	suite.Require().NoError(err)
+	imported.Properties.Title = "testspreadsheet"
+	created, err := suite.service.CreateSpreadsheet(imported)
+	suite.Require().NoError(err)
+	suite.Require().Equal(len(spreadsheet.Sheets), len(created.Sheets))
+
+	sheet, err := spreadsheet.SheetByIndex(0)
+	suite.Require().NoError(err)
+	createdSheet, err := created.SheetByIndex(0)
+	suite.Require().NoError(err)
+	suite.Equal(sheet.Properties.Title, createdSheet.Properties.Title)
+	suite.Equal(sheet.Rows[0][0].Value, createdSheet.Rows[0][0].Value)
+	suite.Equal(len(sheet.Merges), len(createdSheet.Merges))
+}
+
+func (suite *TestSuite) TestSnapshot() {
+	spreadsheet, err := suite.service.FetchSpreadsheet(spreadsheetID)
+	suite.Require().NoError(err)
+	var buf bytes.Buffer
+	suite.Require().NoError(spreadsheet.Save(&buf))
+
+	loaded, err := LoadSnapshot(&buf)
+	suite.Require().NoError(err)
+	suite.Equal(spreadsheet.ID, loaded.ID)
+	suite.Require().Equal(len(spreadsheet.Sheets), len(loaded.Sheets))
+	suite.service.AttachSpreadsheet(&loaded)
+
+	sheet, err := loaded.SheetByID(0)
+	suite.Require().NoError(err)
+	sheet.Update(0, 0, "snapshot")
+	suite.Require().NoError(sheet.Synchronize())
+
+	reloaded, err := suite.service.FetchSpreadsheet(spreadsheetID)
+	suite.Require().NoError(err)
+	reloadedSheet, err := reloaded.SheetByID(0)
+	suite.Require().NoError(err)
+	suite.Equal("snapshot", reloadedSheet.Rows[0][0].Value)
+}
+
+func (suite *TestSuite) TestDeveloperMetadata() {
+	spreadsheet, err := suite.service.FetchSpreadsheet(spreadsheetID)
+	suite.Require().NoError(err)
+	sheet, err := spreadsheet.SheetByTitle("TestSheet2")
+	suite.Require().NoError(err)
+	sheet.Update(100, 0, "order")
+	sheet.Update(100, 1, "1234")
+	suite.Require().NoError(sheet.Synchronize())
+
+	created, err := suite.service.CreateDeveloperMetadata(&spreadsheet, DeveloperMetadata{
+		MetadataKey:   "order",
+		MetadataValue: "1234",
+		Visibility:    "DOCUMENT",
+		Location: DeveloperMetadataLocation{
+			DimensionRange: &DimensionRange{
+				SheetID:    TestSheet2ID,
+				Dimension:  DimensionRows,
+				StartIndex: 100,
+				EndIndex:   101,
+			},
+		},
+	})
+	suite.Require().NoError(err)
+	suite.NotZero(created.MetadataID)
+
+	filter := DataFilter{
+		DeveloperMetadataLookup: &DeveloperMetadataLookup{
+			MetadataKey:   "order",
+			MetadataValue: "1234",
+		},
+	}
+	metadata, err := suite.service.SearchDeveloperMetadata(&spreadsheet, filter)
+	suite.Require().NoError(err)
+	suite.Require().NotEmpty(metadata)
+	suite.Equal(uint(100), metadata[0].Location.DimensionRange.StartIndex)
+
+	valueRanges, err := suite.service.ValuesByDataFilter(&spreadsheet, filter)
+	suite.Require().NoError(err)
+	suite.Require().NotEmpty(valueRanges)
+	suite.Equal([]string{"order", "1234"}, valueRanges[0].Values[0])
+
+	deleted, err := suite.service.DeleteDeveloperMetadata(&spreadsheet, filter)
+	suite.Require().NoError(err)
+	suite.NotEmpty(deleted)
+}
+
 func TestRun(t *testing.T) {
 	suite.Run(t, new(TestSuite))
 }
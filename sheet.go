@@ -2,28 +2,35 @@ package spreadsheet
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // Sheet is a sheet in a spreadsheet.
 type Sheet struct {
-	Properties SheetProperties `json:"properties"`
-	Data       SheetData       `json:"data"`
-	// Merges []*GridRange `json:"merges"`
-	// ConditionalFormats []*ConditionalFormatRule `json:"conditionalFormats"`
-	// FilterViews []*FilterView `json:"filterViews"`
-	// ProtectedRanges []*ProtectedRange `json:"protectedRanges"`
-	// BasicFilter *BasicFilter `json:"basicFilter"`
-	// Charts []*EmbeddedChart `json:"charts"`
-	// BandedRanges []*BandedRange `json:"bandedRanges"`
+	Properties         SheetProperties         `json:"properties"`
+	Data               SheetData               `json:"data"`
+	ConditionalFormats []ConditionalFormatRule `json:"conditionalFormats"`
+	ProtectedRanges    []ProtectedRange        `json:"protectedRanges"`
+	BasicFilter        *BasicFilter            `json:"basicFilter"`
+	FilterViews        []FilterView            `json:"filterViews"`
+	BandedRanges       []BandedRange           `json:"bandedRanges"`
+	Charts             []EmbeddedChart         `json:"charts"`
+	DeveloperMetadata  []DeveloperMetadata     `json:"developerMetadata"`
+	Merges             []GridRange             `json:"merges"`
 
 	Spreadsheet *Spreadsheet `json:"-"`
 	Rows        [][]Cell     `json:"-"`
 	Columns     [][]Cell     `json:"-"`
 
-	modifiedCells []*Cell
-	newMaxRow     uint
-	newMaxColumn  uint
+	modifiedCells  []*Cell
+	newMaxRow      uint
+	newMaxColumn   uint
+	rowMetadata    []DimensionProperties
+	columnMetadata []DimensionProperties
 }
 
 // UnmarshalJSON embeds rows and columns to the sheet.
@@ -35,7 +42,15 @@ func (sheet *Sheet) UnmarshalJSON(data []byte) error {
 	}
 	var maxRow, maxColumn int
 	cells := []Cell{}
+	sheet.rowMetadata = []DimensionProperties{}
+	sheet.columnMetadata = []DimensionProperties{}
 	for _, gridData := range sheet.Data.GridData {
+		for i, meta := range gridData.RowMetadata {
+			sheet.rowMetadata = setDimensionProperties(sheet.rowMetadata, gridData.StartRow+uint(i), meta)
+		}
+		for i, meta := range gridData.ColumnMetadata {
+			sheet.columnMetadata = setDimensionProperties(sheet.columnMetadata, gridData.StartColumn+uint(i), meta)
+		}
 		for rowNum, row := range gridData.RowData {
 			for columnNum, cellData := range row.Values {
 				r := gridData.StartRow + uint(rowNum)
@@ -53,6 +68,12 @@ func (sheet *Sheet) UnmarshalJSON(data []byte) error {
 					Note:           cellData.Note,
 					rawValue:       cellData.UserEnteredValue,
 					effectiveValue: cellData.EffectiveValue,
+					hyperlink:      cellData.Hyperlink,
+					textFormatRuns: cellData.TextFormatRuns,
+					dataValidation: cellData.DataValidation,
+					pivotTable:     cellData.PivotTable,
+					format:         cellData.UserEnteredFormat,
+					sheet:          sheet,
 				}
 				cells = append(cells, cell)
 			}
@@ -80,7 +101,6 @@ func (sheet *Sheet) updateCellField(row, column int, updater func(c *Cell) strin
 		sheet.newMaxColumn = uint(column) + 1
 	}
 
-	var cell *Cell
 	if uint(len(sheet.Rows)) < sheet.newMaxRow+1 ||
 		uint(len(sheet.Columns)) < sheet.newMaxColumn+1 {
 		sheet.Rows = appendCells(sheet.Rows, sheet.newMaxRow, sheet.newMaxColumn, func(i, t uint) Cell {
@@ -89,14 +109,10 @@ func (sheet *Sheet) updateCellField(row, column int, updater func(c *Cell) strin
 		sheet.Columns = appendCells(sheet.Columns, sheet.newMaxColumn, sheet.newMaxRow, func(i, t uint) Cell {
 			return Cell{Row: t, Column: i}
 		})
-		cell = &Cell{
-			Row:    uint(row),
-			Column: uint(column),
-		}
-	} else {
-		cellCopy := sheet.Rows[row][column]
-		cell = &cellCopy
 	}
+	cellCopy := sheet.Rows[row][column]
+	cell := &cellCopy
+	cell.sheet = sheet
 
 	var found bool
 	for _, modifiedCell := range sheet.modifiedCells {
@@ -126,12 +142,59 @@ func (sheet *Sheet) updateCellField(row, column int, updater func(c *Cell) strin
 
 // Update updates cell changes
 func (sheet *Sheet) Update(row, column int, val string) {
+	sheet.updateValue(row, column, val, "")
+}
+
+func (sheet *Sheet) updateValue(row, column int, val, hyperlink string) {
 	sheet.updateCellField(row, column, func(c *Cell) string {
 		c.Value = val
+		c.hyperlink = hyperlink
+		c.rawValue = newExtendedValue(val)
+		// the value of a formula is unknown until it is synchronized
+		c.effectiveValue = c.rawValue
+		if c.rawValue.Kind() == ValueKindFormula {
+			c.effectiveValue = ExtendedValue{}
+		}
+		// a new value erases the previous runs unless they are updated together
+		if strings.Index(c.modifiedFields, "textFormatRuns") == -1 {
+			c.textFormatRuns = nil
+		}
 		return "userEnteredValue"
 	})
 }
 
+// UpdateTime updates a cell with the serial number of the time in the time zone of the spreadsheet
+// and the number format of the type, which is one of NumberFormatDate, NumberFormatTime and NumberFormatDateTime.
+func (sheet *Sheet) UpdateTime(row, column int, t time.Time, formatType string) (err error) {
+	loc, err := sheet.Spreadsheet.Location()
+	if err != nil {
+		return
+	}
+	sheet.updateSerial(row, column, TimeToSerial(t, loc), NumberFormat{Type: formatType})
+	return
+}
+
+// UpdateDuration updates a cell with the serial number of the duration,
+// formatted as elapsed hours, minutes and seconds.
+func (sheet *Sheet) UpdateDuration(row, column int, d time.Duration) {
+	sheet.updateSerial(row, column, DurationToSerial(d), NumberFormat{Type: NumberFormatTime, Pattern: "[h]:mm:ss"})
+}
+
+func (sheet *Sheet) updateSerial(row, column int, serial float64, numberFormat NumberFormat) {
+	sheet.updateValue(row, column, strconv.FormatFloat(serial, 'f', -1, 64), "")
+	sheet.updateCellField(row, column, func(c *Cell) string {
+		c.rawValue = ExtendedValue{NumberValue: serial, kind: ValueKindNumber}
+		c.effectiveValue = c.rawValue
+		format := CellFormat{}
+		if c.format != nil {
+			format = *c.format
+		}
+		format.NumberFormat = &numberFormat
+		c.format = &format
+		return "userEnteredFormat.numberFormat"
+	})
+}
+
 // UpdateNote updates a cell's note
 func (sheet *Sheet) UpdateNote(row, column int, note string) {
 	sheet.updateCellField(row, column, func(c *Cell) string {
@@ -140,6 +203,65 @@ func (sheet *Sheet) UpdateNote(row, column int, note string) {
 	})
 }
 
+// UpdateTextFormatRuns updates the runs of rich text of a cell.
+// The runs overwrite any prior runs, and an empty runs clears them.
+func (sheet *Sheet) UpdateTextFormatRuns(row, column int, runs []TextFormatRun) {
+	sheet.updateCellField(row, column, func(c *Cell) string {
+		c.textFormatRuns = runs
+		return "textFormatRuns"
+	})
+}
+
+// UpdateLink updates a cell with a HYPERLINK formula which shows the text and points to the url.
+func (sheet *Sheet) UpdateLink(row, column int, text, url string) {
+	formula := fmt.Sprintf(`=HYPERLINK("%s","%s")`, escapeFormulaString(url), escapeFormulaString(text))
+	sheet.updateValue(row, column, formula, url)
+}
+
+// UpdateTextLink updates a cell with the text whose whole run links to the url.
+func (sheet *Sheet) UpdateTextLink(row, column int, text, url string) {
+	sheet.Update(row, column, text)
+	sheet.UpdateTextFormatRuns(row, column, []TextFormatRun{
+		{Format: TextFormat{Link: &Link{URI: url}}},
+	})
+}
+
+// Links returns all links in the sheet, from both hyperlinks of cells and links of text format runs.
+func (sheet *Sheet) Links() []CellLink {
+	links := []CellLink{}
+	for _, row := range sheet.Rows {
+		for _, cell := range row {
+			urls := []string{}
+			if cell.hyperlink != "" {
+				urls = append(urls, cell.hyperlink)
+			}
+			for _, run := range cell.textFormatRuns {
+				if run.Format.Link != nil && run.Format.Link.URI != "" {
+					urls = append(urls, run.Format.Link.URI)
+				}
+			}
+			found := map[string]bool{}
+			for _, url := range urls {
+				if found[url] {
+					continue
+				}
+				found[url] = true
+				links = append(links, CellLink{Cell: cell, URL: url})
+			}
+		}
+	}
+	return links
+}
+
+// UpdatePivotTable updates the pivot table anchored at a cell.
+// A nil pivot table removes the pivot table of the cell.
+func (sheet *Sheet) UpdatePivotTable(row, column int, pivotTable *PivotTable) {
+	sheet.updateCellField(row, column, func(c *Cell) string {
+		c.pivotTable = pivotTable
+		return "pivotTable"
+	})
+}
+
 // DeleteRows deletes rows from the sheet
 func (sheet *Sheet) DeleteRows(start, end int) (err error) {
 	err = sheet.Spreadsheet.service.DeleteRows(sheet, start, end)
@@ -152,12 +274,494 @@ func (sheet *Sheet) DeleteColumns(start, end int) (err error) {
 	return
 }
 
+// PasteData pastes the delimited data (e.g. CSV or TSV) with its top left corner at the row and column.
+func (sheet *Sheet) PasteData(row, column int, data, delimiter string) (err error) {
+	err = sheet.Spreadsheet.service.PasteData(sheet, row, column, data, delimiter, PasteNormal)
+	return
+}
+
+// PasteHTML pastes the HTML (e.g. a table) with its top left corner at the row and column.
+func (sheet *Sheet) PasteHTML(row, column int, html string) (err error) {
+	err = sheet.Spreadsheet.service.PasteHTML(sheet, row, column, html, PasteNormal)
+	return
+}
+
+// TextToColumns splits the text of the source range into columns by the delimiter type.
+// The customDelimiter is used only with DelimiterCustom.
+func (sheet *Sheet) TextToColumns(sourceRange GridRange, delimiterType DelimiterType, customDelimiter string) (err error) {
+	err = sheet.Spreadsheet.service.TextToColumns(sheet, sourceRange, delimiterType, customDelimiter)
+	return
+}
+
+// AutoFill fills in the empty cells of the range based on the data in it.
+func (sheet *Sheet) AutoFill(gridRange GridRange, useAlternateSeries bool) (err error) {
+	err = sheet.Spreadsheet.service.AutoFill(sheet, gridRange, useAlternateSeries)
+	return
+}
+
+// AutoFillSourceAndDestination extends the data of the source by the fill length.
+func (sheet *Sheet) AutoFillSourceAndDestination(sourceAndDestination SourceAndDestination, useAlternateSeries bool) (err error) {
+	err = sheet.Spreadsheet.service.AutoFillSourceAndDestination(sheet, sourceAndDestination, useAlternateSeries)
+	return
+}
+
+// ProtectedRangeByID gets a protected range of the sheet by the given ID.
+func (sheet *Sheet) ProtectedRangeByID(id uint) (protectedRange *ProtectedRange, err error) {
+	for i, r := range sheet.ProtectedRanges {
+		if r.ProtectedRangeID == id {
+			protectedRange = &sheet.ProtectedRanges[i]
+			return
+		}
+	}
+	err = errors.New("protected range not found by the id")
+	return
+}
+
+// FilterViewByID gets a filter view of the sheet by the given ID.
+func (sheet *Sheet) FilterViewByID(id uint) (filterView *FilterView, err error) {
+	for i, v := range sheet.FilterViews {
+		if v.FilterViewID == id {
+			filterView = &sheet.FilterViews[i]
+			return
+		}
+	}
+	err = errors.New("filter view not found by the id")
+	return
+}
+
+// BandedRangeByID gets a banded range of the sheet by the given ID.
+func (sheet *Sheet) BandedRangeByID(id uint) (bandedRange *BandedRange, err error) {
+	for i, r := range sheet.BandedRanges {
+		if r.BandedRangeID == id {
+			bandedRange = &sheet.BandedRanges[i]
+			return
+		}
+	}
+	err = errors.New("banded range not found by the id")
+	return
+}
+
+// ChartByID gets a chart of the sheet by the given ID.
+func (sheet *Sheet) ChartByID(id uint) (chart *EmbeddedChart, err error) {
+	for i, c := range sheet.Charts {
+		if c.ChartID == id {
+			chart = &sheet.Charts[i]
+			return
+		}
+	}
+	err = errors.New("chart not found by the id")
+	return
+}
+
+// RowMetadata returns the properties (e.g. height and visibility) of the row.
+func (sheet *Sheet) RowMetadata(row int) DimensionProperties {
+	if row < 0 || row >= len(sheet.rowMetadata) {
+		return DimensionProperties{}
+	}
+	return sheet.rowMetadata[row]
+}
+
+// ColumnMetadata returns the properties (e.g. width and visibility) of the column.
+func (sheet *Sheet) ColumnMetadata(column int) DimensionProperties {
+	if column < 0 || column >= len(sheet.columnMetadata) {
+		return DimensionProperties{}
+	}
+	return sheet.columnMetadata[column]
+}
+
+// RowsHiddenByFilter returns the indexes of the rows hidden by the filter.
+func (sheet *Sheet) RowsHiddenByFilter() []uint {
+	rows := []uint{}
+	for i, meta := range sheet.rowMetadata {
+		if meta.HiddenByFilter {
+			rows = append(rows, uint(i))
+		}
+	}
+	return rows
+}
+
+// SetBasicFilter sets the basic filter of the sheet
+func (sheet *Sheet) SetBasicFilter(filter BasicFilter) (err error) {
+	err = sheet.Spreadsheet.service.SetBasicFilter(sheet, filter)
+	return
+}
+
+// ClearBasicFilter clears the basic filter of the sheet
+func (sheet *Sheet) ClearBasicFilter() (err error) {
+	err = sheet.Spreadsheet.service.ClearBasicFilter(sheet)
+	return
+}
+
+// Rename changes the title of the sheet
+func (sheet *Sheet) Rename(title string) (err error) {
+	props := sheet.Properties
+	props.Title = title
+	err = sheet.Spreadsheet.service.UpdateSheetProperties(sheet, props)
+	return
+}
+
+// MoveTo moves the sheet to the given index, shifting the other sheets
+func (sheet *Sheet) MoveTo(index uint) (err error) {
+	if index >= uint(len(sheet.Spreadsheet.Sheets)) {
+		err = errors.New("sheet index out of range")
+		return
+	}
+	props := sheet.Properties
+	props.Index = index
+	err = sheet.Spreadsheet.service.UpdateSheetProperties(sheet, props)
+	return
+}
+
+// Hide hides the sheet
+func (sheet *Sheet) Hide() (err error) {
+	props := sheet.Properties
+	props.Hidden = true
+	err = sheet.Spreadsheet.service.UpdateSheetProperties(sheet, props)
+	return
+}
+
+// Show unhides the sheet
+func (sheet *Sheet) Show() (err error) {
+	props := sheet.Properties
+	props.Hidden = false
+	err = sheet.Spreadsheet.service.UpdateSheetProperties(sheet, props)
+	return
+}
+
+// SetTabColor sets the color of the sheet tab
+func (sheet *Sheet) SetTabColor(color TabColor) (err error) {
+	props := sheet.Properties
+	props.TabColor = color
+	err = sheet.Spreadsheet.service.UpdateSheetProperties(sheet, props)
+	return
+}
+
+// Freeze freezes the given number of leading rows and columns
+func (sheet *Sheet) Freeze(rows, columns uint) (err error) {
+	props := sheet.Properties
+	props.GridProperties.FrozenRowCount = rows
+	props.GridProperties.FrozenColumnCount = columns
+	err = sheet.Spreadsheet.service.UpdateSheetProperties(sheet, props)
+	return
+}
+
+// SetRightToLeft sets whether the sheet is laid out right to left
+func (sheet *Sheet) SetRightToLeft(rightToLeft bool) (err error) {
+	props := sheet.Properties
+	props.RightToLeft = rightToLeft
+	err = sheet.Spreadsheet.service.UpdateSheetProperties(sheet, props)
+	return
+}
+
+// SetRowHeight sets the height in pixels of the rows from start to end
+func (sheet *Sheet) SetRowHeight(start, end int, pixelSize uint) (err error) {
+	err = sheet.Spreadsheet.service.UpdateDimensionProperties(sheet, DimensionRows, start, end, DimensionProperties{PixelSize: pixelSize}, "pixelSize")
+	return
+}
+
+// SetColumnWidth sets the width in pixels of the columns from start to end
+func (sheet *Sheet) SetColumnWidth(start, end int, pixelSize uint) (err error) {
+	err = sheet.Spreadsheet.service.UpdateDimensionProperties(sheet, DimensionColumns, start, end, DimensionProperties{PixelSize: pixelSize}, "pixelSize")
+	return
+}
+
+// HideRows hides the rows from start to end
+func (sheet *Sheet) HideRows(start, end int) (err error) {
+	err = sheet.Spreadsheet.service.UpdateDimensionProperties(sheet, DimensionRows, start, end, DimensionProperties{HiddenByUser: true}, "hiddenByUser")
+	return
+}
+
+// ShowRows unhides the rows from start to end
+func (sheet *Sheet) ShowRows(start, end int) (err error) {
+	err = sheet.Spreadsheet.service.UpdateDimensionProperties(sheet, DimensionRows, start, end, DimensionProperties{HiddenByUser: false}, "hiddenByUser")
+	return
+}
+
+// HideColumns hides the columns from start to end
+func (sheet *Sheet) HideColumns(start, end int) (err error) {
+	err = sheet.Spreadsheet.service.UpdateDimensionProperties(sheet, DimensionColumns, start, end, DimensionProperties{HiddenByUser: true}, "hiddenByUser")
+	return
+}
+
+// ShowColumns unhides the columns from start to end
+func (sheet *Sheet) ShowColumns(start, end int) (err error) {
+	err = sheet.Spreadsheet.service.UpdateDimensionProperties(sheet, DimensionColumns, start, end, DimensionProperties{HiddenByUser: false}, "hiddenByUser")
+	return
+}
+
+// AutoResizeRows fits the height of the rows from start to end to their contents
+func (sheet *Sheet) AutoResizeRows(start, end int) (err error) {
+	err = sheet.Spreadsheet.service.AutoResizeDimensions(sheet, DimensionRows, start, end)
+	return
+}
+
+// AutoResizeColumns fits the width of the columns from start to end to their contents
+func (sheet *Sheet) AutoResizeColumns(start, end int) (err error) {
+	err = sheet.Spreadsheet.service.AutoResizeDimensions(sheet, DimensionColumns, start, end)
+	return
+}
+
+// conditionalFormatRule returns the rule whose ranges are set to the sheet.
+func (sheet *Sheet) conditionalFormatRule(rule ConditionalFormatRule) ConditionalFormatRule {
+	ranges := make([]GridRange, len(rule.Ranges))
+	for i, gridRange := range rule.Ranges {
+		gridRange.SheetID = sheet.Properties.ID
+		ranges[i] = gridRange
+	}
+	rule.Ranges = ranges
+	return rule
+}
+
+// protectedRange returns the protected range whose ranges are set to the sheet.
+func (sheet *Sheet) protectedRange(protectedRange ProtectedRange) ProtectedRange {
+	if protectedRange.Range != nil {
+		gridRange := *protectedRange.Range
+		gridRange.SheetID = sheet.Properties.ID
+		protectedRange.Range = &gridRange
+	} else if protectedRange.NamedRangeID == "" {
+		protectedRange.Range = &GridRange{SheetID: sheet.Properties.ID}
+	}
+	if protectedRange.UnprotectedRanges != nil {
+		ranges := make([]GridRange, len(protectedRange.UnprotectedRanges))
+		for i, gridRange := range protectedRange.UnprotectedRanges {
+			gridRange.SheetID = sheet.Properties.ID
+			ranges[i] = gridRange
+		}
+		protectedRange.UnprotectedRanges = ranges
+	}
+	return protectedRange
+}
+
+// filterView returns the filter view whose range is set to the sheet.
+func (sheet *Sheet) filterView(filterView FilterView) FilterView {
+	if filterView.Range != nil {
+		gridRange := *filterView.Range
+		gridRange.SheetID = sheet.Properties.ID
+		filterView.Range = &gridRange
+	} else if filterView.NamedRangeID == "" {
+		filterView.Range = &GridRange{SheetID: sheet.Properties.ID}
+	}
+	return filterView
+}
+
+// embeddedObjectPosition returns the position which is overlaid on the sheet if it is not set.
+func (sheet *Sheet) embeddedObjectPosition(position EmbeddedObjectPosition) EmbeddedObjectPosition {
+	if position.SheetID == nil && position.OverlayPosition == nil && !position.NewSheet {
+		position.OverlayPosition = &OverlayPosition{
+			AnchorCell: GridCoordinate{SheetID: sheet.Properties.ID},
+		}
+	}
+	return position
+}
+
+// cellsInRange returns the cells within the grid range.
+// Unbounded sides of the range are clipped to the cells of the sheet.
+func (sheet *Sheet) cellsInRange(gridRange GridRange) [][]Cell {
+	endRow := uint(len(sheet.Rows))
+	if gridRange.EndRowIndex > 0 && gridRange.EndRowIndex < endRow {
+		endRow = gridRange.EndRowIndex
+	}
+	cells := [][]Cell{}
+	for r := gridRange.StartRowIndex; r < endRow; r++ {
+		row := sheet.Rows[r]
+		endColumn := uint(len(row))
+		if gridRange.EndColumnIndex > 0 && gridRange.EndColumnIndex < endColumn {
+			endColumn = gridRange.EndColumnIndex
+		}
+		if gridRange.StartColumnIndex >= endColumn {
+			cells = append(cells, []Cell{})
+			continue
+		}
+		cells = append(cells, append([]Cell{}, row[gridRange.StartColumnIndex:endColumn]...))
+	}
+	return cells
+}
+
+func (sheet *Sheet) setDataValidation(gridRange GridRange, rule *DataValidationRule) {
+	for _, row := range sheet.cellsInRange(gridRange) {
+		for _, cell := range row {
+			sheet.Rows[cell.Row][cell.Column].dataValidation = rule
+			sheet.Columns[cell.Column][cell.Row].dataValidation = rule
+		}
+	}
+	for _, cell := range sheet.modifiedCells {
+		if gridRange.contains(cell.Row, cell.Column) {
+			cell.dataValidation = rule
+		}
+	}
+}
+
+func (sheet *Sheet) updateDimensionMetadata(dimension Dimension, start, end int, updater func(p *DimensionProperties)) {
+	metadata := &sheet.rowMetadata
+	if dimension == DimensionColumns {
+		metadata = &sheet.columnMetadata
+	}
+	for i := start; i < end; i++ {
+		p := DimensionProperties{}
+		if i < len(*metadata) {
+			p = (*metadata)[i]
+		}
+		updater(&p)
+		*metadata = setDimensionProperties(*metadata, uint(i), &p)
+	}
+}
+
+// SetDataValidation sets the data validation rule to all cells in the range.
+// A nil rule clears the data validation of the cells.
+func (sheet *Sheet) SetDataValidation(gridRange GridRange, rule *DataValidationRule) (err error) {
+	err = sheet.Spreadsheet.service.SetDataValidation(sheet, gridRange, rule)
+	return
+}
+
+// Validate checks the pending value changes against the data validation rules of the cells.
+// Conditions which depend on other cells, formulas or dates are not checked.
+func (sheet *Sheet) Validate() error {
+	var errs ValidationErrors
+	for _, cell := range sheet.modifiedCells {
+		if cell.dataValidation == nil || strings.Index(cell.modifiedFields, "userEnteredValue") == -1 {
+			continue
+		}
+		if strings.HasPrefix(cell.Value, "=") {
+			continue
+		}
+		condition := cell.dataValidation.Condition
+		if cell.Value == "" && condition.Type != ConditionNotBlank {
+			continue
+		}
+		if ok, supported := condition.evaluate(cell.Value); supported && !ok {
+			errs = append(errs, &ValidationError{Cell: *cell, Rule: *cell.dataValidation})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// FormulaErrors returns the cells whose effective values are errors such as #REF! and #N/A,
+// in order of rows. Cells still being calculated are not errors.
+func (sheet *Sheet) FormulaErrors() FormulaErrors {
+	var errs FormulaErrors
+	for _, row := range sheet.Rows {
+		for _, cell := range row {
+			if cell.effectiveValue.Kind() != ValueKindError || cell.effectiveValue.ErrorValue.Type == ErrorTypeLoading {
+				continue
+			}
+			errs = append(errs, &FormulaError{Sheet: sheet.Properties.Title, Cell: cell})
+		}
+	}
+	return errs
+}
+
 // Synchronize reflects the changes of the sheet.
 func (sheet *Sheet) Synchronize() (err error) {
 	err = sheet.Spreadsheet.service.SyncSheet(sheet)
 	return
 }
 
+// createParams returns the parameters to create the sheet with its contents.
+func (sheet *Sheet) createParams() map[string]interface{} {
+	props := map[string]interface{}{
+		"title": sheet.Properties.Title,
+	}
+	if sheet.Properties.ID != 0 {
+		props["sheetId"] = sheet.Properties.ID
+	}
+	if sheet.Properties.Hidden {
+		props["hidden"] = true
+	}
+	if sheet.Properties.RightToLeft {
+		props["rightToLeft"] = true
+	}
+	gridProps := map[string]interface{}{}
+	grid := sheet.Properties.GridProperties
+	for key, value := range map[string]uint{
+		"rowCount":          grid.RowCount,
+		"columnCount":       grid.ColumnCount,
+		"frozenRowCount":    grid.FrozenRowCount,
+		"frozenColumnCount": grid.FrozenColumnCount,
+	} {
+		if value > 0 {
+			gridProps[key] = value
+		}
+	}
+	if len(gridProps) > 0 {
+		props["gridProperties"] = gridProps
+	}
+	params := map[string]interface{}{"properties": props}
+
+	rows := []map[string]interface{}{}
+	lastRow := 0
+	for _, row := range sheet.Rows {
+		values := []map[string]interface{}{}
+		lastColumn := 0
+		for _, cell := range row {
+			value := map[string]interface{}{}
+			raw := cell.rawValue
+			if raw.Kind() == ValueKindNone && cell.Value != "" {
+				raw = newExtendedValue(cell.Value)
+			}
+			if raw.Kind() != ValueKindNone && raw.Kind() != ValueKindError {
+				value["userEnteredValue"] = raw
+			}
+			if cell.format != nil {
+				value["userEnteredFormat"] = cell.format
+			}
+			if cell.Note != "" {
+				value["note"] = cell.Note
+			}
+			values = append(values, value)
+			if len(value) > 0 {
+				lastColumn = len(values)
+			}
+		}
+		rows = append(rows, map[string]interface{}{"values": values[:lastColumn]})
+		if lastColumn > 0 {
+			lastRow = len(rows)
+		}
+	}
+	columns := []map[string]interface{}{}
+	for _, meta := range sheet.columnMetadata {
+		column := map[string]interface{}{}
+		if meta.PixelSize > 0 {
+			column["pixelSize"] = meta.PixelSize
+		}
+		if meta.HiddenByUser {
+			column["hiddenByUser"] = true
+		}
+		columns = append(columns, column)
+	}
+	if lastRow > 0 || len(columns) > 0 {
+		params["data"] = []map[string]interface{}{
+			{
+				"startRow":       0,
+				"startColumn":    0,
+				"rowData":        rows[:lastRow],
+				"columnMetadata": columns,
+			},
+		}
+	}
+	if len(sheet.Merges) > 0 {
+		merges := make([]GridRange, len(sheet.Merges))
+		for i, merge := range sheet.Merges {
+			merge.SheetID = sheet.Properties.ID
+			merges[i] = merge
+		}
+		params["merges"] = merges
+	}
+	return params
+}
+
+func setDimensionProperties(metadata []DimensionProperties, index uint, p *DimensionProperties) []DimensionProperties {
+	for uint(len(metadata)) <= index {
+		metadata = append(metadata, DimensionProperties{})
+	}
+	if p != nil {
+		metadata[index] = *p
+	}
+	return metadata
+}
+
 func newCells(maxRow, maxColumn uint) (rows, columns [][]Cell) {
 	rows = make([][]Cell, maxRow+1)
 	for i := uint(0); i < maxRow+1; i++ {
@@ -185,7 +789,7 @@ func appendCells(cells [][]Cell, maxRow, maxColumn uint, cell func(uint, uint) C
 			}
 			cells = append(cells, row)
 		} else {
-			for t := uint(len(cells[i]) - 1); t < maxColumn; t++ {
+			for t := uint(len(cells[i])); t < maxColumn; t++ {
 				cells[i] = append(cells[i], cell(i, t))
 			}
 		}
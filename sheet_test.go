@@ -1,7 +1,9 @@
 package spreadsheet
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -22,6 +24,205 @@ func TestNewCells(t *testing.T) {
 	assert.Equal(uint(2), columns[2][2].Column)
 }
 
+func TestDimensionMetadata(t *testing.T) {
+	assert := assert.New(t)
+	s := Sheet{}
+	s.updateDimensionMetadata(DimensionRows, 2, 4, func(p *DimensionProperties) {
+		p.HiddenByUser = true
+	})
+	s.updateDimensionMetadata(DimensionColumns, 1, 2, func(p *DimensionProperties) {
+		p.PixelSize = 120
+	})
+	assert.False(s.RowMetadata(1).HiddenByUser)
+	assert.True(s.RowMetadata(2).HiddenByUser)
+	assert.True(s.RowMetadata(3).HiddenByUser)
+	assert.False(s.RowMetadata(4).HiddenByUser)
+	assert.Equal(uint(120), s.ColumnMetadata(1).PixelSize)
+	assert.Equal(uint(0), s.ColumnMetadata(-1).PixelSize)
+}
+
+func TestUpdateDimensionPropertiesRequest(t *testing.T) {
+	assert := assert.New(t)
+	r, err := newUpdateRequest(&Spreadsheet{})
+	assert.NoError(err)
+	metadata := []DeveloperMetadata{{MetadataKey: "key", MetadataValue: "value"}}
+	r.UpdateDimensionProperties(DimensionRange{}, DimensionProperties{PixelSize: 10, DeveloperMetadata: metadata}, "pixelSize,developerMetadata")
+	request := r.body["requests"][0]["updateDimensionProperties"].(map[string]interface{})
+	assert.Equal(map[string]interface{}{"pixelSize": uint(10), "developerMetadata": metadata}, request["properties"])
+	assert.Equal("pixelSize,developerMetadata", request["fields"])
+
+	s := Sheet{Spreadsheet: &Spreadsheet{}}
+	err = (&Service{}).UpdateDimensionProperties(&s, DimensionRows, 0, 1, DimensionProperties{}, "pixelSize,hiddenByFilter")
+	assert.EqualError(err, `unsupported dimension property field "hiddenByFilter"`)
+	err = (&Service{}).UpdateDimensionProperties(&s, DimensionRows, 0, 1, DimensionProperties{}, "*")
+	assert.EqualError(err, `unsupported dimension property field "*"`)
+}
+
+func TestProtectedRange(t *testing.T) {
+	assert := assert.New(t)
+	s := Sheet{Properties: SheetProperties{ID: 3}}
+
+	protectedRange := s.protectedRange(ProtectedRange{})
+	assert.Equal(&GridRange{SheetID: 3}, protectedRange.Range)
+
+	protectedRange = s.protectedRange(ProtectedRange{NamedRangeID: "n1"})
+	assert.Nil(protectedRange.Range)
+
+	protectedRange = s.protectedRange(ProtectedRange{
+		Range:             &GridRange{EndColumnIndex: 3},
+		UnprotectedRanges: []GridRange{{StartColumnIndex: 1, EndColumnIndex: 2}},
+	})
+	assert.Equal(uint(3), protectedRange.Range.SheetID)
+	assert.Equal(uint(3), protectedRange.UnprotectedRanges[0].SheetID)
+}
+
+func TestEmbeddedObjectPosition(t *testing.T) {
+	assert := assert.New(t)
+	s := Sheet{Properties: SheetProperties{ID: 3}}
+
+	position := s.embeddedObjectPosition(EmbeddedObjectPosition{})
+	assert.Equal(uint(3), position.OverlayPosition.AnchorCell.SheetID)
+
+	position = s.embeddedObjectPosition(EmbeddedObjectPosition{NewSheet: true})
+	assert.Nil(position.OverlayPosition)
+
+	r, err := newUpdateRequest(&Spreadsheet{})
+	assert.NoError(err)
+	r.UpdateEmbeddedObjectPosition(1, EmbeddedObjectPosition{OverlayPosition: &OverlayPosition{AnchorCell: GridCoordinate{RowIndex: 5}}})
+	r.UpdateEmbeddedObjectPosition(1, EmbeddedObjectPosition{OverlayPosition: &OverlayPosition{WidthPixels: 600, OffsetYPixels: 10}})
+	r.UpdateEmbeddedObjectPosition(1, EmbeddedObjectPosition{NewSheet: true})
+	requests := r.body["requests"]
+	assert.Equal("anchorCell", requests[0]["updateEmbeddedObjectPosition"].(map[string]interface{})["fields"])
+	assert.Equal("anchorCell,offsetYPixels,widthPixels", requests[1]["updateEmbeddedObjectPosition"].(map[string]interface{})["fields"])
+	assert.NotContains(requests[2]["updateEmbeddedObjectPosition"], "fields")
+
+	current := &OverlayPosition{OffsetXPixels: 3, WidthPixels: 400, HeightPixels: 300}
+	moved := &OverlayPosition{AnchorCell: GridCoordinate{RowIndex: 5}, HeightPixels: 200}
+	assert.Equal(&OverlayPosition{AnchorCell: GridCoordinate{RowIndex: 5}, OffsetXPixels: 3, WidthPixels: 400, HeightPixels: 200}, moved.merge(current))
+	assert.Equal(moved, moved.merge(nil))
+}
+
+func TestUpdateSheetPropertiesRequest(t *testing.T) {
+	assert := assert.New(t)
+	s := Sheet{
+		Properties:  SheetProperties{ID: 1, Title: "sheet", Index: 0},
+		Spreadsheet: &Spreadsheet{},
+	}
+	props := s.Properties
+	props.Index = 2
+	props.RightToLeft = true
+	props.GridProperties.FrozenRowCount = 1
+
+	r, err := newUpdateRequest(s.Spreadsheet)
+	assert.NoError(err)
+	r.UpdateSheetProperties(&s, &props)
+	requests := r.body["requests"]
+	assert.Equal(1, len(requests))
+	update := requests[0]["updateSheetProperties"].(map[string]interface{})
+	assert.Equal("index,gridProperties.frozenRowCount,rightToLeft", update["fields"])
+	params := update["properties"].(map[string]interface{})
+	assert.Equal(uint(3), params["index"])
+	assert.Equal(true, params["rightToLeft"])
+
+	r.UpdateSheetProperties(&s, &s.Properties)
+	assert.Equal(1, len(r.body["requests"]))
+}
+
+func TestUpdatePivotTable(t *testing.T) {
+	assert := assert.New(t)
+	s := Sheet{Spreadsheet: &Spreadsheet{}}
+	pivotTable := &PivotTable{
+		Source: GridRange{EndRowIndex: 100, EndColumnIndex: 3},
+		Rows: []PivotGroup{
+			{SourceColumnOffset: 0, ShowTotals: true, SortOrder: SortOrderAscending},
+		},
+		Values: []PivotValue{
+			{SourceColumnOffset: 2, SummarizeFunction: SummarizeSum},
+		},
+		ValueLayout: "HORIZONTAL",
+	}
+	s.UpdatePivotTable(0, 5, pivotTable)
+	assert.Equal(pivotTable, s.Rows[0][5].PivotTable())
+
+	r, err := newUpdateRequest(s.Spreadsheet)
+	assert.NoError(err)
+	r.UpdateCells(&s)
+	requests := r.body["requests"]
+	assert.Equal(1, len(requests))
+	updateCells := requests[0]["updateCells"].(map[string]interface{})
+	assert.Equal("pivotTable", updateCells["fields"])
+	values := updateCells["rows"].([]map[string]interface{})[0]["values"].([]map[string]interface{})
+	assert.Equal(pivotTable, values[0]["pivotTable"])
+}
+
+func TestPivotValueJSON(t *testing.T) {
+	assert := assert.New(t)
+	data, err := json.Marshal(PivotValue{SourceColumnOffset: 0, SummarizeFunction: SummarizeSum})
+	assert.NoError(err)
+	assert.JSONEq(`{"sourceColumnOffset": 0, "summarizeFunction": "SUM"}`, string(data))
+
+	data, err = json.Marshal(PivotValue{Formula: "=SUM(A:A)", SummarizeFunction: SummarizeCustom, Name: "total"})
+	assert.NoError(err)
+	assert.JSONEq(`{"formula": "=SUM(A:A)", "summarizeFunction": "CUSTOM", "name": "total"}`, string(data))
+
+	var value PivotValue
+	assert.NoError(json.Unmarshal([]byte(`{"sourceColumnOffset": 2, "summarizeFunction": "SUM"}`), &value))
+	assert.Equal(PivotValue{SourceColumnOffset: 2, SummarizeFunction: SummarizeSum}, value)
+}
+
+func TestUpdateTextFormatRuns(t *testing.T) {
+	assert := assert.New(t)
+	s := Sheet{Spreadsheet: &Spreadsheet{}}
+	runs := []TextFormatRun{
+		{StartIndex: 0},
+		{StartIndex: 8, Format: TextFormat{Bold: true, ForegroundColor: &Color{Red: 1}}},
+		{StartIndex: 14},
+	}
+	s.Update(0, 0, "Status: FAILED today")
+	s.UpdateTextFormatRuns(0, 0, runs)
+	assert.Equal(runs, s.Rows[0][0].TextFormatRuns())
+
+	r, err := newUpdateRequest(s.Spreadsheet)
+	assert.NoError(err)
+	r.UpdateCells(&s)
+	updateCells := r.body["requests"][0]["updateCells"].(map[string]interface{})
+	assert.Equal("userEnteredValue,textFormatRuns", updateCells["fields"])
+	values := updateCells["rows"].([]map[string]interface{})[0]["values"].([]map[string]interface{})
+	assert.Equal(runs, values[0]["textFormatRuns"])
+
+	s.Update(0, 0, "Status: OK")
+	assert.Equal(runs, s.Rows[0][0].TextFormatRuns())
+
+	s = Sheet{}
+	s.UpdateTextFormatRuns(0, 0, runs)
+	s.modifiedCells = []*Cell{}
+	s.Update(0, 0, "Status: OK")
+	assert.Nil(s.Rows[0][0].TextFormatRuns())
+}
+
+func TestUpdateLink(t *testing.T) {
+	assert := assert.New(t)
+	s := Sheet{}
+	s.UpdateLink(0, 0, `say "hi"`, "https://example.com/a")
+	s.UpdateTextLink(1, 1, "docs", "https://example.com/b")
+	s.Update(2, 0, "plain")
+
+	assert.Equal(`=HYPERLINK("https://example.com/a","say ""hi""")`, s.Rows[0][0].Value)
+	assert.Equal("https://example.com/a", s.Rows[0][0].Hyperlink())
+	assert.Equal("docs", s.Rows[1][1].Value)
+
+	links := s.Links()
+	assert.Equal(2, len(links))
+	assert.Equal("A1", links[0].Cell.Pos())
+	assert.Equal("https://example.com/a", links[0].URL)
+	assert.Equal("B2", links[1].Cell.Pos())
+	assert.Equal("https://example.com/b", links[1].URL)
+
+	s.Update(0, 0, "no link")
+	assert.Equal("", s.Rows[0][0].Hyperlink())
+	assert.Equal(1, len(s.Links()))
+}
+
 func benchmarkUpdate(t int, b *testing.B) {
 	for f := 0; f < b.N; f++ {
 		s := Sheet{}
@@ -36,3 +237,213 @@ func BenchmarkUpdate1(b *testing.B)    { benchmarkUpdate(1, b) }
 func BenchmarkUpdate10(b *testing.B)   { benchmarkUpdate(10, b) }
 func BenchmarkUpdate100(b *testing.B)  { benchmarkUpdate(100, b) }
 func BenchmarkUpdate1000(b *testing.B) { benchmarkUpdate(1000, b) }
+
+func TestUpdateExpandsCells(t *testing.T) {
+	assert := assert.New(t)
+	s := Sheet{}
+	s.Update(0, 0, "a")
+	s.Update(2, 3, "b")
+	s.Update(1, 4, "c")
+	for i, row := range s.Rows {
+		for t, cell := range row {
+			assert.Equal(uint(i), cell.Row)
+			assert.Equal(uint(t), cell.Column)
+		}
+	}
+	assert.Equal("b", s.Rows[2][3].Value)
+	assert.Equal("c", s.Columns[4][1].Value)
+}
+
+func TestAppendCells(t *testing.T) {
+	assert := assert.New(t)
+	cells := [][]Cell{{{Row: 0, Column: 0}, {Row: 0, Column: 1}}, {}}
+	cells = appendCells(cells, 3, 4, func(i, t uint) Cell {
+		return Cell{Row: i, Column: t}
+	})
+	assert.Equal(3, len(cells))
+	for i, row := range cells {
+		assert.Equal(4, len(row))
+		for t, cell := range row {
+			assert.Equal(uint(i), cell.Row)
+			assert.Equal(uint(t), cell.Column)
+		}
+	}
+}
+
+func TestUpdateKeepsCellFields(t *testing.T) {
+	assert := assert.New(t)
+	var s Sheet
+	err := json.Unmarshal([]byte(`{
+		"properties": {"sheetId": 0, "title": "Sheet1", "gridProperties": {"rowCount": 10, "columnCount": 5}},
+		"data": [{"rowData": [{"values": [{
+			"formattedValue": "yes",
+			"note": "answer",
+			"userEnteredFormat": {"textFormat": {"bold": true}},
+			"dataValidation": {"condition": {"type": "ONE_OF_LIST", "values": [{"userEnteredValue": "yes"}, {"userEnteredValue": "no"}]}}
+		}]}]}]
+	}`), &s)
+	assert.NoError(err)
+
+	// the update expands the cells to the grid, which must keep the fields of the cell
+	s.Update(0, 0, "no")
+	for _, cell := range []Cell{s.Rows[0][0], s.Columns[0][0], *s.modifiedCells[0]} {
+		assert.Equal("no", cell.Value)
+		assert.Equal("answer", cell.Note)
+		assert.True(cell.UserEnteredFormat().TextFormat.Bold)
+		assert.NotNil(cell.DataValidation())
+	}
+	assert.Equal(10, len(s.Rows))
+	assert.Equal(5, len(s.Rows[0]))
+}
+
+func TestValidate(t *testing.T) {
+	assert := assert.New(t)
+	s := Sheet{}
+	s.Update(3, 1, "")
+	s.setDataValidation(GridRange{EndRowIndex: 5, StartColumnIndex: 1, EndColumnIndex: 2}, &DataValidationRule{
+		Condition: BooleanCondition{
+			Type:   ConditionOneOfList,
+			Values: []ConditionValue{{UserEnteredValue: "yes"}, {UserEnteredValue: "no"}},
+		},
+	})
+	s.setDataValidation(GridRange{StartColumnIndex: 0, EndColumnIndex: 1}, &DataValidationRule{
+		Condition: BooleanCondition{
+			Type:   ConditionNumberBetween,
+			Values: []ConditionValue{{UserEnteredValue: "1"}, {UserEnteredValue: "10"}},
+		},
+	})
+	s.Update(0, 1, "yes")
+	s.Update(1, 1, "maybe")
+	s.Update(0, 0, "5")
+	s.Update(1, 0, "11")
+	s.Update(2, 0, "=A1*3")
+	s.Update(3, 1, "")
+
+	err := s.Validate()
+	assert.Error(err)
+	errs, ok := err.(ValidationErrors)
+	assert.True(ok)
+	assert.Equal(2, len(errs))
+	assert.Equal("B2", errs[0].Cell.Pos())
+	assert.Equal("A2", errs[1].Cell.Pos())
+	assert.NotNil(s.Rows[1][1].DataValidation())
+
+	s.Update(1, 1, "no")
+	s.Update(1, 0, "10")
+	assert.NoError(s.Validate())
+}
+
+func TestEvaluateCondition(t *testing.T) {
+	assert := assert.New(t)
+	cases := []struct {
+		condition BooleanCondition
+		value     string
+		ok        bool
+		supported bool
+	}{
+		{BooleanCondition{Type: ConditionBoolean}, "TRUE", true, true},
+		{BooleanCondition{Type: ConditionBoolean}, "yes", false, true},
+		{BooleanCondition{Type: ConditionNumberGreater, Values: []ConditionValue{{UserEnteredValue: "3"}}}, "3.5", true, true},
+		{BooleanCondition{Type: ConditionNumberLessEq, Values: []ConditionValue{{UserEnteredValue: "3"}}}, "4", false, true},
+		{BooleanCondition{Type: ConditionNumberEq, Values: []ConditionValue{{UserEnteredValue: "3"}}}, "three", false, true},
+		{BooleanCondition{Type: ConditionTextContains, Values: []ConditionValue{{UserEnteredValue: "ok"}}}, "looks ok", true, true},
+		{BooleanCondition{Type: ConditionTextIsEmail}, "foo@example.com", true, true},
+		{BooleanCondition{Type: ConditionTextIsURL}, "example", false, true},
+		{BooleanCondition{Type: ConditionNotBlank}, "", false, true},
+		{BooleanCondition{Type: ConditionCustomFormula, Values: []ConditionValue{{UserEnteredValue: "=A1>0"}}}, "1", false, false},
+		{BooleanCondition{Type: ConditionOneOfRange, Values: []ConditionValue{{UserEnteredValue: "=Sheet1!A1:A3"}}}, "1", false, false},
+		{BooleanCondition{Type: ConditionDateBefore, Values: []ConditionValue{{RelativeDate: "TODAY"}}}, "1", false, false},
+	}
+	for _, c := range cases {
+		ok, supported := c.condition.evaluate(c.value)
+		assert.Equal(c.ok, ok, "%s %q", c.condition.Type, c.value)
+		assert.Equal(c.supported, supported, "%s %q", c.condition.Type, c.value)
+	}
+}
+
+func TestUpdateTime(t *testing.T) {
+	assert := assert.New(t)
+	s := Sheet{Spreadsheet: &Spreadsheet{Properties: Properties{TimeZone: "UTC"}}}
+	tm := time.Date(2020, 1, 1, 21, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+	assert.NoError(s.UpdateTime(0, 0, tm, NumberFormatDateTime))
+	s.UpdateDuration(0, 1, 90*time.Minute)
+
+	cell := s.Rows[0][0]
+	assert.Equal("43831.5", cell.Value)
+	got, err := cell.Time()
+	assert.NoError(err)
+	assert.True(tm.Equal(got))
+	assert.Equal(time.UTC, got.Location())
+	d, err := s.Rows[0][1].Duration()
+	assert.NoError(err)
+	assert.Equal(90*time.Minute, d)
+
+	r, err := newUpdateRequest(s.Spreadsheet)
+	assert.NoError(err)
+	r.UpdateCells(&s)
+	requests := r.body["requests"]
+	assert.Equal(1, len(requests))
+	updateCells := requests[0]["updateCells"].(map[string]interface{})
+	assert.Equal("userEnteredValue,userEnteredFormat.numberFormat", updateCells["fields"])
+	values := updateCells["rows"].([]map[string]interface{})[0]["values"].([]map[string]interface{})
+	assert.Equal(2, len(values))
+	assert.Equal(map[string]string{"numberValue": "43831.5"}, values[0]["userEnteredValue"])
+	assert.Equal(&NumberFormat{Type: NumberFormatDateTime}, values[0]["userEnteredFormat"].(map[string]interface{})["numberFormat"])
+	assert.Equal(&NumberFormat{Type: NumberFormatTime, Pattern: "[h]:mm:ss"}, values[1]["userEnteredFormat"].(map[string]interface{})["numberFormat"])
+
+	s.Spreadsheet.Properties.TimeZone = "Nowhere/Unknown"
+	assert.Error(s.UpdateTime(1, 0, tm, NumberFormatDate))
+}
+
+func TestUpdateValueKind(t *testing.T) {
+	assert := assert.New(t)
+	s := Sheet{Spreadsheet: &Spreadsheet{}}
+	s.Update(0, 0, "12")
+	s.Update(0, 1, "TRUE")
+	s.Update(0, 2, "=A1*2")
+	s.Update(0, 3, "text")
+
+	i, ok := s.Rows[0][0].Int()
+	assert.True(ok)
+	assert.Equal(12, i)
+	b, ok := s.Rows[0][1].Bool()
+	assert.True(ok)
+	assert.True(b)
+	formula, ok := s.Rows[0][2].Formula()
+	assert.True(ok)
+	assert.Equal("=A1*2", formula)
+	assert.Equal(ValueKindNone, s.Rows[0][2].EffectiveValue().Kind())
+	str, ok := s.Rows[0][3].String()
+	assert.True(ok)
+	assert.Equal("text", str)
+}
+
+func TestCreateParams(t *testing.T) {
+	assert := assert.New(t)
+	s := Sheet{Properties: SheetProperties{Title: "sheet"}}
+	assert.Equal(map[string]interface{}{
+		"properties": map[string]interface{}{"title": "sheet"},
+	}, s.createParams())
+
+	s = Sheet{
+		Properties: SheetProperties{ID: 2, Title: "sheet", GridProperties: GridProperties{FrozenRowCount: 1}},
+		Merges:     []GridRange{{EndRowIndex: 1, EndColumnIndex: 2}},
+	}
+	s.Rows, s.Columns = newCells(2, 2)
+	s.Update(0, 1, "=A1")
+	s.UpdateNote(1, 0, "note")
+	s.columnMetadata = setDimensionProperties(nil, 0, &DimensionProperties{PixelSize: 120})
+	params := s.createParams()
+	assert.Equal(map[string]interface{}{
+		"title":          "sheet",
+		"sheetId":        uint(2),
+		"gridProperties": map[string]interface{}{"frozenRowCount": uint(1)},
+	}, params["properties"])
+	data := params["data"].([]map[string]interface{})[0]
+	rows := data["rowData"].([]map[string]interface{})
+	assert.Equal(2, len(rows))
+	assert.Equal([]map[string]interface{}{{}, {"userEnteredValue": ExtendedValue{FormulaValue: "=A1", kind: ValueKindFormula}}}, rows[0]["values"])
+	assert.Equal([]map[string]interface{}{{"note": "note"}}, rows[1]["values"])
+	assert.Equal([]map[string]interface{}{{"pixelSize": uint(120)}}, data["columnMetadata"])
+	assert.Equal([]GridRange{{SheetID: 2, EndRowIndex: 1, EndColumnIndex: 2}}, params["merges"])
+}
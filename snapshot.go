@@ -0,0 +1,223 @@
+package spreadsheet
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// SnapshotVersion is the version of the snapshot format written by Save.
+const SnapshotVersion = 1
+
+// Types of records in a snapshot.
+const (
+	snapshotSpreadsheet = "spreadsheet"
+	snapshotSheet       = "sheet"
+	snapshotCell        = "cell"
+)
+
+type snapshotHeader struct {
+	Type              string              `json:"type"`
+	Version           int                 `json:"version"`
+	SavedAt           time.Time           `json:"savedAt"`
+	ID                string              `json:"spreadsheetId"`
+	Properties        Properties          `json:"properties"`
+	NamedRanges       []NamedRange        `json:"namedRanges"`
+	DeveloperMetadata []DeveloperMetadata `json:"developerMetadata"`
+}
+
+type snapshotCellRecord struct {
+	Type    string `json:"type"`
+	SheetID uint   `json:"sheetId"`
+	Row     uint   `json:"row"`
+	Column  uint   `json:"column"`
+	CellData
+}
+
+// Save writes a snapshot of the spreadsheet to w as newline delimited JSON.
+// The first record has the version and the properties of the spreadsheet,
+// which is followed by a record of each sheet and the records of its non-empty cells.
+// Changes of cells which are not synchronized are saved as their values.
+func (spreadsheet *Spreadsheet) Save(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	err := encoder.Encode(snapshotHeader{
+		Type:              snapshotSpreadsheet,
+		Version:           SnapshotVersion,
+		SavedAt:           time.Now().UTC(),
+		ID:                spreadsheet.ID,
+		Properties:        spreadsheet.Properties,
+		NamedRanges:       spreadsheet.NamedRanges,
+		DeveloperMetadata: spreadsheet.DeveloperMetadata,
+	})
+	if err != nil {
+		return err
+	}
+	for i := range spreadsheet.Sheets {
+		sheet := &spreadsheet.Sheets[i]
+		// the data as fetched is replaced with the records of the cells
+		withoutData := *sheet
+		withoutData.Data = SheetData{}
+		data, err := json.Marshal(withoutData)
+		if err != nil {
+			return err
+		}
+		record := map[string]interface{}{}
+		if err := json.Unmarshal(data, &record); err != nil {
+			return err
+		}
+		delete(record, "data")
+		record["type"] = snapshotSheet
+		record["rowMetadata"] = sheet.rowMetadata
+		record["columnMetadata"] = sheet.columnMetadata
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+
+		for _, row := range sheet.Rows {
+			for _, cell := range row {
+				cellData := cell.data()
+				if cellData == nil {
+					continue
+				}
+				err := encoder.Encode(snapshotCellRecord{
+					Type:     snapshotCell,
+					SheetID:  sheet.Properties.ID,
+					Row:      cell.Row,
+					Column:   cell.Column,
+					CellData: *cellData,
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// data returns the data of the cell, or nil if the cell is empty.
+func (cell *Cell) data() *CellData {
+	cellData := &CellData{
+		UserEnteredValue:  cell.rawValue,
+		EffectiveValue:    cell.effectiveValue,
+		FormattedValue:    cell.Value,
+		UserEnteredFormat: cell.format,
+		Hyperlink:         cell.hyperlink,
+		Note:              cell.Note,
+		TextFormatRuns:    cell.textFormatRuns,
+		DataValidation:    cell.dataValidation,
+		PivotTable:        cell.pivotTable,
+	}
+	if cell.rawValue.Kind() == ValueKindNone && cell.effectiveValue.Kind() == ValueKindNone &&
+		cell.Value == "" && cell.format == nil && cell.hyperlink == "" && cell.Note == "" &&
+		len(cell.textFormatRuns) == 0 && cell.dataValidation == nil && cell.pivotTable == nil {
+		return nil
+	}
+	return cellData
+}
+
+// LoadSnapshot reads a snapshot written by Save.
+// The spreadsheet is not attached to any service until Service.AttachSpreadsheet is called,
+// and its sheets must not be updated before that, since they do not point to the returned spreadsheet yet.
+func LoadSnapshot(r io.Reader) (spreadsheet Spreadsheet, err error) {
+	decoder := json.NewDecoder(r)
+	var header snapshotHeader
+	if err = decoder.Decode(&header); err != nil {
+		return
+	}
+	if header.Type != snapshotSpreadsheet {
+		err = fmt.Errorf("snapshot starts with %q record instead of %q", header.Type, snapshotSpreadsheet)
+		return
+	}
+	if header.Version < 1 || header.Version > SnapshotVersion {
+		err = fmt.Errorf("unsupported snapshot version %d", header.Version)
+		return
+	}
+
+	sheets := []map[string]interface{}{}
+	rowsBySheetID := map[uint]*[]RowData{}
+	gridsBySheetID := map[uint]GridProperties{}
+	for decoder.More() {
+		var raw json.RawMessage
+		if err = decoder.Decode(&raw); err != nil {
+			return
+		}
+		var record map[string]json.RawMessage
+		if err = json.Unmarshal(raw, &record); err != nil {
+			return
+		}
+		var recordType string
+		if err = json.Unmarshal(record["type"], &recordType); err != nil {
+			return
+		}
+		switch recordType {
+		case snapshotSheet:
+			var properties SheetProperties
+			if err = json.Unmarshal(record["properties"], &properties); err != nil {
+				return
+			}
+			rows := []RowData{}
+			rowsBySheetID[properties.ID] = &rows
+			grid := properties.GridProperties
+			if grid.RowCount == 0 && grid.ColumnCount == 0 {
+				grid.RowCount, grid.ColumnCount = maxRowNumber, maxColumnNumber
+			}
+			gridsBySheetID[properties.ID] = grid
+			sheet := map[string]interface{}{}
+			for key, value := range record {
+				if key != "type" && key != "rowMetadata" && key != "columnMetadata" {
+					sheet[key] = value
+				}
+			}
+			sheet["data"] = []map[string]interface{}{
+				{
+					"rowData":        &rows,
+					"rowMetadata":    record["rowMetadata"],
+					"columnMetadata": record["columnMetadata"],
+				},
+			}
+			sheets = append(sheets, sheet)
+		case snapshotCell:
+			var cell snapshotCellRecord
+			if err = json.Unmarshal(raw, &cell); err != nil {
+				return
+			}
+			rows, ok := rowsBySheetID[cell.SheetID]
+			if !ok {
+				err = fmt.Errorf("cell of unknown sheet %d in snapshot", cell.SheetID)
+				return
+			}
+			// the rows are allocated up to the cell, which must be within the grid of its sheet or XFD1048576
+			if grid := gridsBySheetID[cell.SheetID]; cell.Row >= grid.RowCount || cell.Column >= grid.ColumnCount {
+				err = fmt.Errorf("cell %d,%d is out of the grid of sheet %d in snapshot", cell.Row, cell.Column, cell.SheetID)
+				return
+			}
+			for uint(len(*rows)) <= cell.Row {
+				*rows = append(*rows, RowData{})
+			}
+			row := &(*rows)[cell.Row]
+			for uint(len(row.Values)) <= cell.Column {
+				row.Values = append(row.Values, CellData{})
+			}
+			row.Values[cell.Column] = cell.CellData
+		default:
+			err = fmt.Errorf("unknown record %q in snapshot", recordType)
+			return
+		}
+	}
+
+	// rebuild the spreadsheet in the form of the API so that it is unmarshaled as fetched
+	data, err := json.Marshal(map[string]interface{}{
+		"spreadsheetId":     header.ID,
+		"properties":        header.Properties,
+		"namedRanges":       header.NamedRanges,
+		"developerMetadata": header.DeveloperMetadata,
+		"sheets":            sheets,
+	})
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(data, &spreadsheet)
+	return
+}
@@ -0,0 +1,98 @@
+package spreadsheet
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot(t *testing.T) {
+	assert := assert.New(t)
+	var spreadsheet Spreadsheet
+	require.NoError(t, json.Unmarshal([]byte(testSpreadsheetJSON), &spreadsheet))
+	sheet, err := spreadsheet.SheetByID(1)
+	require.NoError(t, err)
+	sheet.Update(3, 2, "=1/0")
+	sheet.Rows[0][0].Note = "first"
+
+	var buf bytes.Buffer
+	require.NoError(t, spreadsheet.Save(&buf))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Contains(lines[0], `"version":1`)
+
+	loaded, err := LoadSnapshot(&buf)
+	require.NoError(t, err)
+	assert.Nil(loaded.service)
+	assert.Equal("test", loaded.ID)
+	assert.Equal(spreadsheet.Properties, loaded.Properties)
+	assert.Equal(spreadsheet.NamedRanges, loaded.NamedRanges)
+	loc, err := loaded.Location()
+	require.NoError(t, err)
+	assert.Equal("Asia/Tokyo", loc.String())
+	require.Equal(t, 2, len(loaded.Sheets))
+
+	first, err := loaded.SheetByID(0)
+	require.NoError(t, err)
+	// the sheets point to the spreadsheet made while loading until it is attached
+	assert.NotSame(&loaded, first.Spreadsheet)
+	service := &Service{}
+	service.AttachSpreadsheet(&loaded)
+	assert.Same(&loaded, first.Spreadsheet)
+	assert.Same(service, loaded.service)
+	assert.NotNil(first.Rows[0][0].PivotTable())
+	tm, err := first.Rows[0][1].Time()
+	assert.NoError(err)
+	assert.Equal(2020, tm.Year())
+	assert.Equal("#DIV/0!", first.Rows[0][5].Value)
+	assert.Equal(ValueKindFormula, first.Rows[0][5].rawValue.Kind())
+	assert.Equal(ValueKindError, first.Rows[0][5].effectiveValue.Kind())
+	assert.Equal(ValueKindString, first.Rows[0][3].rawValue.Kind())
+
+	second, err := loaded.SheetByID(1)
+	require.NoError(t, err)
+	assert.Equal(sheet.ConditionalFormats, second.ConditionalFormats)
+	assert.Equal(sheet.BasicFilter, second.BasicFilter)
+	assert.Equal("first", second.Rows[0][0].Note)
+	assert.Equal("f", second.Rows[2][1].Value)
+	assert.Equal(2, len(second.Rows[1][1].TextFormatRuns()))
+	assert.Equal("=1/0", second.Rows[3][2].Value)
+	formula, ok := second.Rows[3][2].Formula()
+	assert.True(ok)
+	assert.Equal("=1/0", formula)
+	assert.Equal(sheet.Rows[3][2].Value, second.Columns[2][3].Value)
+	assert.True(second.RowMetadata(1).HiddenByFilter)
+	assert.Equal("1234", second.RowMetadata(2).DeveloperMetadata[0].MetadataValue)
+	assert.Equal(uint(120), second.ColumnMetadata(1).PixelSize)
+	assert.Equal(sheet.Properties.GridProperties, second.Properties.GridProperties)
+}
+
+func TestLoadSnapshotErrors(t *testing.T) {
+	_, err := LoadSnapshot(strings.NewReader(`{"type":"spreadsheet","version":2}`))
+	assert.EqualError(t, err, "unsupported snapshot version 2")
+
+	_, err = LoadSnapshot(strings.NewReader(`{"type":"sheet","version":1}`))
+	assert.Error(t, err)
+
+	_, err = LoadSnapshot(strings.NewReader(`{"type":"spreadsheet","version":1}
+{"type":"cell","sheetId":3,"row":0,"column":0}`))
+	assert.EqualError(t, err, "cell of unknown sheet 3 in snapshot")
+
+	_, err = LoadSnapshot(strings.NewReader(`{"type":"spreadsheet","version":1}
+{"type":"sheet","properties":{"sheetId":3,"gridProperties":{"rowCount":10,"columnCount":5}}}
+{"type":"cell","sheetId":3,"row":9,"column":4}
+{"type":"cell","sheetId":3,"row":4000000000,"column":0}`))
+	assert.EqualError(t, err, "cell 4000000000,0 is out of the grid of sheet 3 in snapshot")
+
+	_, err = LoadSnapshot(strings.NewReader(`{"type":"spreadsheet","version":1}
+{"type":"sheet","properties":{"sheetId":3}}
+{"type":"cell","sheetId":3,"row":1048576,"column":0}`))
+	assert.EqualError(t, err, "cell 1048576,0 is out of the grid of sheet 3 in snapshot")
+
+	_, err = LoadSnapshot(strings.NewReader(`{"type":"spreadsheet","version":1}
+{"type":"chart"}`))
+	assert.EqualError(t, err, `unknown record "chart" in snapshot`)
+}
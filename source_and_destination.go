@@ -0,0 +1,11 @@
+package spreadsheet
+
+// SourceAndDestination is a combination of a source range and how to extend that source.
+type SourceAndDestination struct {
+	Source    GridRange `json:"source"`
+	Dimension Dimension `json:"dimension"`
+	// FillLength is the number of rows or columns that data should be filled into.
+	// Positive numbers expand beyond the last row or last column of the source.
+	// Negative numbers expand before the first row or first column of the source.
+	FillLength int `json:"fillLength"`
+}
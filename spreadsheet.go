@@ -3,30 +3,66 @@ package spreadsheet
 import (
 	"encoding/json"
 	"errors"
+	"time"
 )
 
 // Spreadsheet represents a spreadsheet.
 type Spreadsheet struct {
-	ID         string     `json:"spreadsheetId"`
-	Properties Properties `json:"properties"`
-	Sheets     []Sheet    `json:"sheets"`
-	// NamedRanges []*NamedRange `json:"namedRanges"`
+	ID                string              `json:"spreadsheetId"`
+	Properties        Properties          `json:"properties"`
+	Sheets            []Sheet             `json:"sheets"`
+	NamedRanges       []NamedRange        `json:"namedRanges"`
+	DeveloperMetadata []DeveloperMetadata `json:"developerMetadata"`
 
 	service *Service
 	cached  bool
+	// syncedProperties is a deep copy of the properties as they were fetched or last updated.
+	// The changes of Properties are found against it,
+	// since the pointers in a copy of Properties still point to the same values.
+	syncedProperties Properties
 }
 
 // UnmarshalJSON embeds spreadsheet to sheets.
+// The sheets are unmarshaled in place, so that their cells keep pointing to them.
 func (spreadsheet *Spreadsheet) UnmarshalJSON(data []byte) error {
 	type Alias Spreadsheet
-	a := (*Alias)(spreadsheet)
-	if err := json.Unmarshal(data, a); err != nil {
+	a := struct {
+		*Alias
+		Sheets []json.RawMessage `json:"sheets"`
+	}{Alias: (*Alias)(spreadsheet)}
+	if err := json.Unmarshal(data, &a); err != nil {
 		return err
 	}
+	if a.Sheets != nil {
+		spreadsheet.Sheets = make([]Sheet, len(a.Sheets))
+	}
+	for i := range a.Sheets {
+		if err := json.Unmarshal(a.Sheets[i], &spreadsheet.Sheets[i]); err != nil {
+			return err
+		}
+	}
 	for i := range spreadsheet.Sheets {
 		spreadsheet.Sheets[i].Spreadsheet = spreadsheet
 	}
-	return nil
+	return deepCopy(&spreadsheet.syncedProperties, spreadsheet.Properties)
+}
+
+// FormulaErrors returns the formula errors in all sheets of the spreadsheet.
+func (spreadsheet *Spreadsheet) FormulaErrors() FormulaErrors {
+	var errs FormulaErrors
+	for i := range spreadsheet.Sheets {
+		errs = append(errs, spreadsheet.Sheets[i].FormulaErrors()...)
+	}
+	return errs
+}
+
+// Location returns the location of the time zone of the spreadsheet.
+// It returns UTC if the spreadsheet has no time zone.
+func (spreadsheet *Spreadsheet) Location() (*time.Location, error) {
+	if spreadsheet.Properties.TimeZone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(spreadsheet.Properties.TimeZone)
 }
 
 // SheetByIndex gets a sheet by the given index.
@@ -64,3 +100,56 @@ func (spreadsheet *Spreadsheet) SheetByTitle(title string) (sheet *Sheet, err er
 	err = errors.New("sheet not found by the title")
 	return
 }
+
+// NamedRangeByID gets a named range by the given ID.
+func (spreadsheet *Spreadsheet) NamedRangeByID(id string) (namedRange *NamedRange, err error) {
+	for i, r := range spreadsheet.NamedRanges {
+		if r.NamedRangeID == id {
+			namedRange = &spreadsheet.NamedRanges[i]
+			return
+		}
+	}
+	err = errors.New("named range not found by the id")
+	return
+}
+
+// NamedRangeByName gets a named range by the given name.
+func (spreadsheet *Spreadsheet) NamedRangeByName(name string) (namedRange *NamedRange, err error) {
+	for i, r := range spreadsheet.NamedRanges {
+		if r.Name == name {
+			namedRange = &spreadsheet.NamedRanges[i]
+			return
+		}
+	}
+	err = errors.New("named range not found by the name")
+	return
+}
+
+// RangeByName gets the sheet and the cells of the named range.
+// The cells are ordered by rows and then columns.
+func (spreadsheet *Spreadsheet) RangeByName(name string) (sheet *Sheet, cells [][]Cell, err error) {
+	namedRange, err := spreadsheet.NamedRangeByName(name)
+	if err != nil {
+		return
+	}
+	sheet, err = spreadsheet.SheetByID(namedRange.Range.SheetID)
+	if err != nil {
+		return
+	}
+	cells = sheet.cellsInRange(namedRange.Range)
+	return
+}
+
+// createParams returns the parameters to create the spreadsheet with its sheets.
+func (spreadsheet *Spreadsheet) createParams() map[string]interface{} {
+	sheets := make([]map[string]interface{}, 0, len(spreadsheet.Sheets))
+	for i := range spreadsheet.Sheets {
+		sheets = append(sheets, spreadsheet.Sheets[i].createParams())
+	}
+	return map[string]interface{}{
+		"properties": map[string]interface{}{
+			"title": spreadsheet.Properties.Title,
+		},
+		"sheets": sheets,
+	}
+}
@@ -0,0 +1,362 @@
+package spreadsheet
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSpreadsheetJSON = `{
+	"spreadsheetId": "test",
+	"properties": {
+		"title": "test",
+		"locale": "en_US",
+		"autoRecalc": "ON_CHANGE",
+		"timeZone": "Asia/Tokyo",
+		"defaultFormat": {"backgroundColor": {"red": 1, "green": 1, "blue": 1}, "padding": {"top": 2, "right": 3, "bottom": 2, "left": 3}, "textFormat": {"fontFamily": "arial,sans,sans-serif", "fontSize": 10}},
+		"iterativeCalculationSettings": {"maxIterations": 50, "convergenceThreshold": 0.05}
+	},
+	"namedRanges": [
+		{"namedRangeId": "n1", "name": "Totals", "range": {"sheetId": 1, "startRowIndex": 1, "endRowIndex": 3, "startColumnIndex": 1, "endColumnIndex": 2}},
+		{"namedRangeId": "n2", "name": "ColumnA", "range": {"sheetId": 1, "endColumnIndex": 1}}
+	],
+	"sheets": [
+		{
+			"properties": {"sheetId": 0, "title": "Sheet1", "index": 0},
+			"data": [{"rowData": [
+				{"values": [{"pivotTable": {
+					"source": {"sheetId": 1, "endRowIndex": 3, "endColumnIndex": 2},
+					"rows": [{"sourceColumnOffset": 0, "showTotals": true, "sortOrder": "ASCENDING", "valueBucket": {"valuesIndex": 0, "buckets": [{"stringValue": "a"}]}}],
+					"values": [{"sourceColumnOffset": 1, "summarizeFunction": "COUNTA"}],
+					"valueLayout": "HORIZONTAL"
+				}}, {"formattedValue": "2020/01/01 12:00:00", "userEnteredValue": {"numberValue": 43831.5}, "effectiveValue": {"numberValue": 43831.5}, "userEnteredFormat": {"numberFormat": {"type": "DATE_TIME"}}},
+					{"formattedValue": "0", "userEnteredValue": {"numberValue": 0}, "effectiveValue": {"numberValue": 0}},
+					{"formattedValue": "", "userEnteredValue": {"stringValue": ""}, "effectiveValue": {"stringValue": ""}},
+					{"formattedValue": "FALSE", "userEnteredValue": {"formulaValue": "=1>2"}, "effectiveValue": {"boolValue": false}},
+					{"formattedValue": "#DIV/0!", "userEnteredValue": {"formulaValue": "=1/0"}, "effectiveValue": {"errorValue": {"type": "DIVIDE_BY_ZERO", "message": "Function DIVIDE parameter 2 cannot be zero."}}}
+				]}
+			]}]
+		},
+		{
+			"properties": {"sheetId": 1, "title": "Sheet2", "index": 1},
+			"conditionalFormats": [
+				{"ranges": [{"sheetId": 1, "endRowIndex": 3}], "booleanRule": {"condition": {"type": "TEXT_CONTAINS", "values": [{"userEnteredValue": "a"}]}, "format": {"textFormat": {"bold": true}}}},
+				{"ranges": [{"sheetId": 1}], "gradientRule": {"minpoint": {"color": {"red": 1}, "type": "MIN"}, "maxpoint": {"color": {"green": 1}, "type": "MAX"}}}
+			],
+			"basicFilter": {
+				"range": {"sheetId": 1, "endRowIndex": 3, "endColumnIndex": 2},
+				"sortSpecs": [{"dimensionIndex": 1, "sortOrder": "DESCENDING"}],
+				"criteria": {"0": {"hiddenValues": ["c"]}}
+			},
+			"data": [{
+				"rowData": [
+					{"values": [{"formattedValue": "a"}, {"formattedValue": "b"}]},
+					{"values": [{"formattedValue": "c"}, {"formattedValue": "d", "textFormatRuns": [{"format": {}}, {"startIndex": 0, "format": {"italic": true, "link": {"uri": "https://example.com"}}}]}]},
+					{"values": [{"formattedValue": "e"}, {"formattedValue": "f"}]}
+				],
+				"rowMetadata": [
+					{"pixelSize": 21},
+					{"pixelSize": 21, "hiddenByFilter": true},
+					{"pixelSize": 21, "developerMetadata": [{"metadataId": 7, "metadataKey": "order", "metadataValue": "1234", "visibility": "DOCUMENT", "location": {"locationType": "ROW", "dimensionRange": {"sheetId": 1, "dimension": "ROWS", "startIndex": 2, "endIndex": 3}}}]}
+				],
+				"columnMetadata": [{"pixelSize": 100}, {"pixelSize": 120}]
+			}]
+		}
+	]
+}`
+
+func TestRangeByName(t *testing.T) {
+	assert := assert.New(t)
+	var spreadsheet Spreadsheet
+	require.NoError(t, json.Unmarshal([]byte(testSpreadsheetJSON), &spreadsheet))
+
+	sheet, cells, err := spreadsheet.RangeByName("Totals")
+	require.NoError(t, err)
+	assert.Equal("Sheet2", sheet.Properties.Title)
+	assert.Equal(2, len(cells))
+	assert.Equal(1, len(cells[0]))
+	assert.Equal("d", cells[0][0].Value)
+	assert.Equal("f", cells[1][0].Value)
+
+	_, cells, err = spreadsheet.RangeByName("ColumnA")
+	require.NoError(t, err)
+	assert.Equal(3, len(cells))
+	assert.Equal("e", cells[2][0].Value)
+
+	_, _, err = spreadsheet.RangeByName("Unknown")
+	assert.Error(err)
+}
+
+func TestConditionalFormats(t *testing.T) {
+	assert := assert.New(t)
+	var spreadsheet Spreadsheet
+	require.NoError(t, json.Unmarshal([]byte(testSpreadsheetJSON), &spreadsheet))
+
+	sheet, err := spreadsheet.SheetByID(1)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(sheet.ConditionalFormats))
+	rule := sheet.ConditionalFormats[0]
+	require.NotNil(t, rule.BooleanRule)
+	assert.Equal(ConditionTextContains, rule.BooleanRule.Condition.Type)
+	assert.Equal("a", rule.BooleanRule.Condition.Values[0].UserEnteredValue)
+	assert.True(rule.BooleanRule.Format.TextFormat.Bold)
+	rule = sheet.ConditionalFormats[1]
+	require.NotNil(t, rule.GradientRule)
+	assert.Equal(InterpolationPointMax, rule.GradientRule.Maxpoint.Type)
+	assert.Nil(rule.GradientRule.Midpoint)
+
+	rule = sheet.conditionalFormatRule(ConditionalFormatRule{Ranges: []GridRange{{SheetID: 5}}})
+	assert.Equal(uint(1), rule.Ranges[0].SheetID)
+}
+
+func TestBasicFilter(t *testing.T) {
+	assert := assert.New(t)
+	var spreadsheet Spreadsheet
+	require.NoError(t, json.Unmarshal([]byte(testSpreadsheetJSON), &spreadsheet))
+
+	sheet, err := spreadsheet.SheetByID(1)
+	require.NoError(t, err)
+	require.NotNil(t, sheet.BasicFilter)
+	assert.Equal(uint(3), sheet.BasicFilter.Range.EndRowIndex)
+	assert.Equal(SortOrderDescending, sheet.BasicFilter.SortSpecs[0].SortOrder)
+	assert.Equal([]string{"c"}, sheet.BasicFilter.Criteria["0"].HiddenValues)
+	assert.Equal([]uint{1}, sheet.RowsHiddenByFilter())
+	assert.True(sheet.RowMetadata(1).HiddenByFilter)
+	assert.Equal(uint(120), sheet.ColumnMetadata(1).PixelSize)
+
+	sheet, err = spreadsheet.SheetByID(0)
+	require.NoError(t, err)
+	assert.Nil(sheet.BasicFilter)
+	assert.Empty(sheet.RowsHiddenByFilter())
+}
+
+func TestPivotTable(t *testing.T) {
+	assert := assert.New(t)
+	var spreadsheet Spreadsheet
+	require.NoError(t, json.Unmarshal([]byte(testSpreadsheetJSON), &spreadsheet))
+
+	sheet, err := spreadsheet.SheetByID(0)
+	require.NoError(t, err)
+	pivotTable := sheet.Rows[0][0].PivotTable()
+	require.NotNil(t, pivotTable)
+	assert.Equal(uint(1), pivotTable.Source.SheetID)
+	assert.Equal(SortOrderAscending, pivotTable.Rows[0].SortOrder)
+	assert.Equal("a", pivotTable.Rows[0].ValueBucket.Buckets[0].StringValue)
+	assert.Equal(SummarizeCountA, pivotTable.Values[0].SummarizeFunction)
+
+	data, err := json.Marshal(pivotTable.Rows[0].ValueBucket)
+	require.NoError(t, err)
+	assert.JSONEq(`{"valuesIndex": 0, "buckets": [{"stringValue": "a"}]}`, string(data))
+}
+
+func TestTextFormatRuns(t *testing.T) {
+	assert := assert.New(t)
+	var spreadsheet Spreadsheet
+	require.NoError(t, json.Unmarshal([]byte(testSpreadsheetJSON), &spreadsheet))
+
+	sheet, err := spreadsheet.SheetByID(1)
+	require.NoError(t, err)
+	runs := sheet.Rows[1][1].TextFormatRuns()
+	require.Equal(t, 2, len(runs))
+	assert.True(runs[1].Format.Italic)
+	assert.Equal("https://example.com", runs[1].Format.Link.URI)
+	assert.Nil(sheet.Rows[0][0].TextFormatRuns())
+}
+
+func TestDeveloperMetadata(t *testing.T) {
+	assert := assert.New(t)
+	var spreadsheet Spreadsheet
+	require.NoError(t, json.Unmarshal([]byte(testSpreadsheetJSON), &spreadsheet))
+
+	sheet, err := spreadsheet.SheetByID(1)
+	require.NoError(t, err)
+	metadata := sheet.RowMetadata(2).DeveloperMetadata
+	require.Equal(t, 1, len(metadata))
+	assert.Equal(uint(7), metadata[0].MetadataID)
+	assert.Equal("1234", metadata[0].MetadataValue)
+	assert.Equal("ROW", metadata[0].Location.LocationType)
+	assert.Equal(DimensionRows, metadata[0].Location.DimensionRange.Dimension)
+	assert.Equal(uint(2), metadata[0].Location.DimensionRange.StartIndex)
+	assert.Empty(sheet.RowMetadata(1).DeveloperMetadata)
+}
+
+func TestProperties(t *testing.T) {
+	assert := assert.New(t)
+	var spreadsheet Spreadsheet
+	require.NoError(t, json.Unmarshal([]byte(testSpreadsheetJSON), &spreadsheet))
+
+	properties := spreadsheet.Properties
+	assert.Equal("Asia/Tokyo", properties.TimeZone)
+	assert.Equal(3, properties.DefaultFormat.Padding.Right)
+	assert.Equal(50, properties.IterativeCalculationSettings.MaxIterations)
+
+	r, err := newUpdateRequest(&spreadsheet)
+	require.NoError(t, err)
+	r.UpdateSpreadsheetProperties(&properties)
+	assert.Empty(r.body["requests"])
+
+	properties.Title = "renamed"
+	properties.TimeZone = "America/New_York"
+	properties.IterativeCalculationSettings = nil
+	r.UpdateSpreadsheetProperties(&properties)
+	require.Equal(t, 1, len(r.body["requests"]))
+	update := r.body["requests"][0]["updateSpreadsheetProperties"].(map[string]interface{})
+	assert.Equal("title,timeZone,iterativeCalculationSettings", update["fields"])
+	params := update["properties"].(map[string]interface{})
+	assert.Equal("America/New_York", params["timeZone"])
+	assert.NotContains(params, "locale")
+
+	// a copy of the properties shares the default format with the spreadsheet
+	r, err = newUpdateRequest(&spreadsheet)
+	require.NoError(t, err)
+	properties = spreadsheet.Properties
+	properties.DefaultFormat.Padding.Right = 4
+	properties.IterativeCalculationSettings.MaxIterations = 100
+	assert.Equal(4, spreadsheet.Properties.DefaultFormat.Padding.Right)
+	r.UpdateSpreadsheetProperties(&properties)
+	require.Equal(t, 1, len(r.body["requests"]))
+	update = r.body["requests"][0]["updateSpreadsheetProperties"].(map[string]interface{})
+	assert.Equal("defaultFormat,iterativeCalculationSettings", update["fields"])
+}
+
+func TestCellTime(t *testing.T) {
+	assert := assert.New(t)
+	var spreadsheet Spreadsheet
+	require.NoError(t, json.Unmarshal([]byte(testSpreadsheetJSON), &spreadsheet))
+	loc, err := spreadsheet.Location()
+	require.NoError(t, err)
+	assert.Equal("Asia/Tokyo", loc.String())
+
+	sheet, err := spreadsheet.SheetByID(0)
+	require.NoError(t, err)
+	cell := sheet.Rows[0][1]
+	assert.Equal(NumberFormatDateTime, cell.UserEnteredFormat().NumberFormat.Type)
+	tm, err := cell.Time()
+	assert.NoError(err)
+	assert.Equal(time.Date(2020, 1, 1, 12, 0, 0, 0, loc), tm)
+	assert.Equal(loc, tm.Location())
+	tm, err = cell.TimeIn(time.UTC)
+	assert.NoError(err)
+	assert.Equal(time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC), tm)
+	d, err := cell.Duration()
+	assert.NoError(err)
+	assert.Equal(43831*24*time.Hour+12*time.Hour, d)
+
+	_, err = sheet.Rows[0][0].Time()
+	assert.Error(err)
+	_, err = sheet.Rows[0][0].Duration()
+	assert.Error(err)
+
+	// a value updated as it is is also converted in the time zone of the spreadsheet
+	sheet.Update(1, 0, "43831.25")
+	tm, err = sheet.Rows[1][0].Time()
+	assert.NoError(err)
+	assert.Equal(time.Date(2020, 1, 1, 6, 0, 0, 0, loc), tm)
+
+	// the time zone is looked up when the time is converted
+	spreadsheet.Properties.TimeZone = "UTC"
+	tm, err = cell.Time()
+	assert.NoError(err)
+	assert.Equal(time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC), tm)
+	spreadsheet.Properties.TimeZone = "Nowhere/Unknown"
+	_, err = cell.Time()
+	assert.Error(err)
+
+	// a cell of no spreadsheet is converted in UTC
+	tm, err = (&Cell{effectiveValue: ExtendedValue{NumberValue: 1, kind: ValueKindNumber}}).Time()
+	assert.NoError(err)
+	assert.Equal(time.Date(1899, 12, 31, 0, 0, 0, 0, time.UTC), tm)
+}
+
+func TestCellValues(t *testing.T) {
+	assert := assert.New(t)
+	var spreadsheet Spreadsheet
+	require.NoError(t, json.Unmarshal([]byte(testSpreadsheetJSON), &spreadsheet))
+	sheet, err := spreadsheet.SheetByID(0)
+	require.NoError(t, err)
+	row := sheet.Rows[0]
+
+	assert.Equal(ValueKindNone, row[0].EffectiveValue().Kind())
+	_, ok := row[0].Float()
+	assert.False(ok)
+
+	f, ok := row[1].Float()
+	assert.True(ok)
+	assert.Equal(43831.5, f)
+	_, ok = row[1].Int()
+	assert.False(ok)
+
+	i, ok := row[2].Int()
+	assert.True(ok)
+	assert.Equal(0, i)
+	_, ok = row[2].String()
+	assert.False(ok)
+
+	s, ok := row[3].String()
+	assert.True(ok)
+	assert.Equal("", s)
+
+	b, ok := row[4].Bool()
+	assert.True(ok)
+	assert.False(b)
+	formula, ok := row[4].Formula()
+	assert.True(ok)
+	assert.Equal("=1>2", formula)
+	_, ok = row[3].Formula()
+	assert.False(ok)
+
+	assert.NoError(row[4].Err())
+	err = row[5].Err()
+	assert.Error(err)
+	assert.Equal("DIVIDE_BY_ZERO: Function DIVIDE parameter 2 cannot be zero.", err.Error())
+	_, err = row[5].Time()
+	assert.Equal(row[5].Err(), err)
+	_, err = row[3].Time()
+	assert.Error(err)
+}
+
+func TestFormulaErrors(t *testing.T) {
+	assert := assert.New(t)
+	var spreadsheet Spreadsheet
+	require.NoError(t, json.Unmarshal([]byte(testSpreadsheetJSON), &spreadsheet))
+	sheet, err := spreadsheet.SheetByID(0)
+	require.NoError(t, err)
+
+	errs := sheet.FormulaErrors()
+	require.Equal(t, 1, len(errs))
+	assert.Equal("Sheet1", errs[0].Sheet)
+	assert.Equal("F1", errs[0].Cell.Pos())
+	assert.Equal(ErrorTypeDivideByZero, errs[0].Value().Type)
+	assert.Equal("#DIV/0! at 'Sheet1'!F1: Function DIVIDE parameter 2 cannot be zero.", errs[0].Error())
+	assert.Equal(errs, spreadsheet.FormulaErrors())
+
+	sheet.Rows[0][5].effectiveValue.ErrorValue = ErrorValue{Type: ErrorTypeLoading}
+	assert.Empty(sheet.FormulaErrors())
+	assert.Nil(spreadsheet.FormulaErrors())
+	assert.Equal("#N/A", ErrorValue{Type: ErrorTypeNA}.Code())
+}
+
+func TestSpreadsheetCreateParams(t *testing.T) {
+	assert := assert.New(t)
+	spreadsheet := Spreadsheet{
+		Properties: Properties{Title: "imported"},
+		Sheets: []Sheet{
+			{Properties: SheetProperties{Title: "Sheet1"}},
+			{Properties: SheetProperties{Title: "Sheet2"}},
+		},
+	}
+	params := spreadsheet.createParams()
+	assert.Equal(map[string]interface{}{"title": "imported"}, params["properties"])
+	assert.Equal([]map[string]interface{}{
+		{"properties": map[string]interface{}{"title": "Sheet1"}},
+		{"properties": map[string]interface{}{"title": "Sheet2"}},
+	}, params["sheets"])
+
+	empty := Spreadsheet{}
+	data, err := json.Marshal(empty.createParams())
+	require.NoError(t, err)
+	assert.JSONEq(`{"properties": {"title": ""}, "sheets": []}`, string(data))
+}
@@ -0,0 +1,15 @@
+package spreadsheet
+
+// TextFormat is the format of a run of text in a cell.
+type TextFormat struct {
+	ForegroundColor *Color `json:"foregroundColor,omitempty"`
+	FontFamily      string `json:"fontFamily,omitempty"`
+	FontSize        uint   `json:"fontSize,omitempty"`
+	Bold            bool   `json:"bold,omitempty"`
+	Italic          bool   `json:"italic,omitempty"`
+	Strikethrough   bool   `json:"strikethrough,omitempty"`
+	Underline       bool   `json:"underline,omitempty"`
+	// Link is the link destination of the text, if any.
+	// It is available only in the text format runs of a cell.
+	Link *Link `json:"link,omitempty"`
+}
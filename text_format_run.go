@@ -0,0 +1,9 @@
+package spreadsheet
+
+// TextFormatRun is a run of a text format.
+// The format of this run continues until the start index of the next run.
+type TextFormatRun struct {
+	// StartIndex is the character index where this run starts.
+	StartIndex int        `json:"startIndex,omitempty"`
+	Format     TextFormat `json:"format"`
+}
@@ -1,8 +1,10 @@
 package spreadsheet
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 )
 
@@ -26,6 +28,12 @@ type updateRequest struct {
 }
 
 func (r *updateRequest) Do() (err error) {
+	_, err = r.DoWithResponse()
+	return
+}
+
+// DoWithResponse sends the requests and returns the replies of them in the same order.
+func (r *updateRequest) DoWithResponse() (res *updateResponse, err error) {
 	if len(r.body["requests"]) == 0 {
 		err = errors.New("Requests must not be empty")
 		return
@@ -35,12 +43,55 @@ func (r *updateRequest) Do() (err error) {
 	for k, v := range r.body {
 		params[k] = v
 	}
-	_, err = r.spreadsheet.service.post(path, params)
+	body, err := r.spreadsheet.service.post(path, params)
+	if err != nil {
+		return
+	}
+	res = &updateResponse{}
+	err = json.Unmarshal([]byte(body), res)
 	return
 }
 
-func (r *updateRequest) UpdateSpreadsheetProperties() {
-
+// UpdateSpreadsheetProperties updates the properties of the spreadsheet which differ from the ones last synchronized.
+func (r *updateRequest) UpdateSpreadsheetProperties(properties *Properties) (ret *updateRequest) {
+	ret = r
+	current := r.spreadsheet.syncedProperties
+	params := map[string]interface{}{}
+	fields := []string{}
+	if properties.Title != current.Title {
+		params["title"] = properties.Title
+		fields = append(fields, "title")
+	}
+	if properties.Locale != current.Locale {
+		params["locale"] = properties.Locale
+		fields = append(fields, "locale")
+	}
+	if properties.AutoRecalc != current.AutoRecalc {
+		params["autoRecalc"] = properties.AutoRecalc
+		fields = append(fields, "autoRecalc")
+	}
+	if properties.TimeZone != current.TimeZone {
+		params["timeZone"] = properties.TimeZone
+		fields = append(fields, "timeZone")
+	}
+	if !reflect.DeepEqual(properties.DefaultFormat, current.DefaultFormat) {
+		params["defaultFormat"] = properties.DefaultFormat
+		fields = append(fields, "defaultFormat")
+	}
+	if !reflect.DeepEqual(properties.IterativeCalculationSettings, current.IterativeCalculationSettings) {
+		params["iterativeCalculationSettings"] = properties.IterativeCalculationSettings
+		fields = append(fields, "iterativeCalculationSettings")
+	}
+	if len(fields) == 0 {
+		return
+	}
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"updateSpreadsheetProperties": map[string]interface{}{
+			"properties": params,
+			"fields":     strings.Join(fields, ","),
+		},
+	})
+	return
 }
 
 func (r *updateRequest) UpdateSheetProperties(sheet *Sheet, sheetProperties *SheetProperties) (ret *updateRequest) {
@@ -54,7 +105,13 @@ func (r *updateRequest) UpdateSheetProperties(sheet *Sheet, sheetProperties *She
 		fields = append(fields, "title")
 	}
 	if sheetProperties.Index != sheet.Properties.Index {
-		params["index"] = sheetProperties.Index
+		// The API counts indexes from before the move,
+		// so moving a sheet forward needs one more.
+		index := sheetProperties.Index
+		if index > sheet.Properties.Index {
+			index++
+		}
+		params["index"] = index
 		fields = append(fields, "index")
 	}
 	gridParams := make(map[string]interface{}, 0)
@@ -92,7 +149,7 @@ func (r *updateRequest) UpdateSheetProperties(sheet *Sheet, sheetProperties *She
 		fields = append(fields, "tabColor")
 	}
 	if sheetProperties.RightToLeft != sheet.Properties.RightToLeft {
-		params["rightToLeft"] = sheet.Properties.RightToLeft
+		params["rightToLeft"] = sheetProperties.RightToLeft
 		fields = append(fields, "rightToLeft")
 	}
 	if len(fields) == 0 {
@@ -107,24 +164,77 @@ func (r *updateRequest) UpdateSheetProperties(sheet *Sheet, sheetProperties *She
 	return
 }
 
-func (r *updateRequest) UpdateDimensionProperties() {
-
+// UpdateDimensionProperties updates properties of dimensions within the range
+func (r *updateRequest) UpdateDimensionProperties(dimensionRange DimensionRange, properties DimensionProperties, fields string) *updateRequest {
+	params := map[string]interface{}{}
+	for _, field := range strings.Split(fields, ",") {
+		switch field {
+		case "pixelSize":
+			params["pixelSize"] = properties.PixelSize
+		case "hiddenByUser":
+			params["hiddenByUser"] = properties.HiddenByUser
+		case "developerMetadata":
+			params["developerMetadata"] = properties.DeveloperMetadata
+		}
+	}
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"updateDimensionProperties": map[string]interface{}{
+			"range":      dimensionRange,
+			"properties": params,
+			"fields":     fields,
+		},
+	})
+	return r
 }
 
-func (r *updateRequest) UpdateNamedRange() {
-
+// UpdateNamedRange updates the changed fields of the named range
+func (r *updateRequest) UpdateNamedRange(current, namedRange NamedRange) *updateRequest {
+	params := map[string]interface{}{
+		"namedRangeId": current.NamedRangeID,
+	}
+	fields := []string{}
+	if namedRange.Name != current.Name {
+		params["name"] = namedRange.Name
+		fields = append(fields, "name")
+	}
+	if namedRange.Range != current.Range {
+		params["range"] = namedRange.Range
+		fields = append(fields, "range")
+	}
+	if len(fields) == 0 {
+		return r
+	}
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"updateNamedRange": map[string]interface{}{
+			"namedRange": params,
+			"fields":     strings.Join(fields, ","),
+		},
+	})
+	return r
 }
 
 func (r *updateRequest) RepeatCell() {
 
 }
 
-func (r *updateRequest) AddNamedRange() {
-
+// AddNamedRange adds a named range
+func (r *updateRequest) AddNamedRange(namedRange NamedRange) *updateRequest {
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"addNamedRange": map[string]interface{}{
+			"namedRange": namedRange,
+		},
+	})
+	return r
 }
 
-func (r *updateRequest) DeleteNamedRange() {
-
+// DeleteNamedRange deletes the named range
+func (r *updateRequest) DeleteNamedRange(namedRangeID string) *updateRequest {
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"deleteNamedRange": map[string]interface{}{
+			"namedRangeId": namedRangeID,
+		},
+	})
+	return r
 }
 
 func (r *updateRequest) AddSheet(sheetProperties SheetProperties) *updateRequest {
@@ -145,16 +255,51 @@ func (r *updateRequest) DeleteSheet(sheetID uint) *updateRequest {
 	return r
 }
 
-func (r *updateRequest) AutoFill() {
-
+// AutoFill fills in more data based on existing data in the range
+func (r *updateRequest) AutoFill(gridRange GridRange, useAlternateSeries bool) *updateRequest {
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"autoFill": map[string]interface{}{
+			"range":              gridRange,
+			"useAlternateSeries": useAlternateSeries,
+		},
+	})
+	return r
 }
 
-func (r *updateRequest) CutPaste() {
-
+// AutoFillSourceAndDestination fills in more data by extending the source
+func (r *updateRequest) AutoFillSourceAndDestination(sourceAndDestination SourceAndDestination, useAlternateSeries bool) *updateRequest {
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"autoFill": map[string]interface{}{
+			"sourceAndDestination": sourceAndDestination,
+			"useAlternateSeries":   useAlternateSeries,
+		},
+	})
+	return r
 }
 
-func (r *updateRequest) CopyPaste() {
+// CutPaste moves data from the source to the destination
+func (r *updateRequest) CutPaste(source GridRange, destination GridCoordinate, pasteType PasteType) *updateRequest {
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"cutPaste": map[string]interface{}{
+			"source":      source,
+			"destination": destination,
+			"pasteType":   pasteType,
+		},
+	})
+	return r
+}
 
+// CopyPaste copies data from the source to the destination
+func (r *updateRequest) CopyPaste(source, destination GridRange, pasteType PasteType, orientation PasteOrientation) *updateRequest {
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"copyPaste": map[string]interface{}{
+			"source":           source,
+			"destination":      destination,
+			"pasteType":        pasteType,
+			"pasteOrientation": orientation,
+		},
+	})
+	return r
 }
 
 func (r *updateRequest) MergeCells() {
@@ -170,6 +315,8 @@ func (r *updateRequest) UpdateBorders() {
 }
 
 func (r *updateRequest) UpdateCells(sheet *Sheet) *updateRequest {
+	var last *Cell
+	var row map[string]interface{}
 	for _, cell := range sheet.modifiedCells {
 		values := map[string]interface{}{}
 		for _, field := range strings.Split(cell.modifiedFields, ",") {
@@ -178,18 +325,38 @@ func (r *updateRequest) UpdateCells(sheet *Sheet) *updateRequest {
 				values["userEnteredValue"] = map[string]string{
 					cellValueType(cell.Value): cell.Value,
 				}
+			case "userEnteredFormat.numberFormat":
+				if cell.format != nil {
+					values["userEnteredFormat"] = map[string]interface{}{
+						"numberFormat": cell.format.NumberFormat,
+					}
+				}
 			case "note":
 				values["note"] = cell.Note
+			case "textFormatRuns":
+				values["textFormatRuns"] = cell.textFormatRuns
+			case "pivotTable":
+				if cell.pivotTable != nil {
+					values["pivotTable"] = cell.pivotTable
+				}
 			}
 		}
+		// cells next to each other in a row with the same fields share a request
+		if last != nil && cell.Row == last.Row && cell.Column == last.Column+1 && cell.modifiedFields == last.modifiedFields {
+			row["values"] = append(row["values"].([]map[string]interface{}), values)
+			last = cell
+			continue
+		}
+		last = cell
+		row = map[string]interface{}{
+			"values": []map[string]interface{}{
+				values,
+			},
+		}
 		r.body["requests"] = append(r.body["requests"], map[string]interface{}{
 			"updateCells": map[string]interface{}{
 				"rows": []map[string]interface{}{
-					map[string]interface{}{
-						"values": []map[string]interface{}{
-							values,
-						},
-					},
+					row,
 				},
 				"fields": cell.modifiedFields,
 				"start": map[string]interface{}{
@@ -203,20 +370,32 @@ func (r *updateRequest) UpdateCells(sheet *Sheet) *updateRequest {
 	return r
 }
 
-func (r *updateRequest) AddFilterView() {
-
+// AddFilterView adds a filter view
+func (r *updateRequest) AddFilterView(filterView FilterView) *updateRequest {
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"addFilterView": map[string]interface{}{
+			"filter": filterView,
+		},
+	})
+	return r
 }
 
 func (r *updateRequest) AppendCells() {
 
 }
 
-func (r *updateRequest) ClearBasicFilter() {
-
+// ClearBasicFilter clears the basic filter of the sheet
+func (r *updateRequest) ClearBasicFilter(sheet *Sheet) *updateRequest {
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"clearBasicFilter": map[string]interface{}{
+			"sheetId": sheet.Properties.ID,
+		},
+	})
+	return r
 }
 
 // DeleteDemension deletes rows or columns
-func (r *updateRequest) DeleteDimension(sheet *Sheet, dimension string, start, end int) (ret *updateRequest) {
+func (r *updateRequest) DeleteDimension(sheet *Sheet, dimension Dimension, start, end int) (ret *updateRequest) {
 	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
 		"deleteDimension": map[string]interface{}{
 			"range": map[string]interface{}{
@@ -230,16 +409,34 @@ func (r *updateRequest) DeleteDimension(sheet *Sheet, dimension string, start, e
 	return r
 }
 
-func (r *updateRequest) DeleteEmbeddedObject() {
-
+// DeleteEmbeddedObject deletes the embedded object such as a chart
+func (r *updateRequest) DeleteEmbeddedObject(objectID uint) *updateRequest {
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"deleteEmbeddedObject": map[string]interface{}{
+			"objectId": objectID,
+		},
+	})
+	return r
 }
 
-func (r *updateRequest) DeleteFilterView() {
-
+// DeleteFilterView deletes the filter view
+func (r *updateRequest) DeleteFilterView(filterViewID uint) *updateRequest {
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"deleteFilterView": map[string]interface{}{
+			"filterId": filterViewID,
+		},
+	})
+	return r
 }
 
-func (r *updateRequest) DuplicateFilterView() {
-
+// DuplicateFilterView duplicates the filter view
+func (r *updateRequest) DuplicateFilterView(filterViewID uint) *updateRequest {
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"duplicateFilterView": map[string]interface{}{
+			"filterId": filterViewID,
+		},
+	})
+	return r
 }
 
 // DuplicateSheet duplicates the contents of a sheet
@@ -266,82 +463,342 @@ func (r *updateRequest) MoveDimension() {
 
 }
 
-func (r *updateRequest) UpdateEmbeddedObjectPosition() {
-
+// UpdateEmbeddedObjectPosition moves the embedded object to the new position
+func (r *updateRequest) UpdateEmbeddedObjectPosition(objectID uint, position EmbeddedObjectPosition) *updateRequest {
+	params := map[string]interface{}{
+		"objectId":    objectID,
+		"newPosition": position,
+	}
+	if position.OverlayPosition != nil {
+		params["fields"] = position.OverlayPosition.fields()
+	}
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"updateEmbeddedObjectPosition": params,
+	})
+	return r
 }
 
-func (r *updateRequest) PasteData() {
-
+// PasteData inserts data into the sheet starting at the coordinate.
+// The data is parsed as HTML if html is true, otherwise it is split by the delimiter.
+func (r *updateRequest) PasteData(coordinate GridCoordinate, data, delimiter string, html bool, pasteType PasteType) *updateRequest {
+	params := map[string]interface{}{
+		"coordinate": coordinate,
+		"data":       data,
+		"type":       pasteType,
+	}
+	if html {
+		params["html"] = true
+	} else {
+		params["delimiter"] = delimiter
+	}
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"pasteData": params,
+	})
+	return r
 }
 
-func (r *updateRequest) TextToColumns() {
-
+// TextToColumns splits a column of text into multiple columns
+func (r *updateRequest) TextToColumns(source GridRange, delimiterType DelimiterType, delimiter string) *updateRequest {
+	params := map[string]interface{}{
+		"source":        source,
+		"delimiterType": delimiterType,
+	}
+	if delimiterType == DelimiterCustom {
+		params["delimiter"] = delimiter
+	}
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"textToColumns": params,
+	})
+	return r
 }
 
-func (r *updateRequest) UpdateFilterView() {
-
+// UpdateFilterView updates the changed fields of the filter view
+func (r *updateRequest) UpdateFilterView(current, filterView FilterView) *updateRequest {
+	params := map[string]interface{}{
+		"filterViewId": current.FilterViewID,
+	}
+	fields := []string{}
+	if filterView.Title != current.Title {
+		params["title"] = filterView.Title
+		fields = append(fields, "title")
+	}
+	if !reflect.DeepEqual(filterView.Range, current.Range) {
+		params["range"] = filterView.Range
+		fields = append(fields, "range")
+	}
+	if filterView.NamedRangeID != current.NamedRangeID {
+		params["namedRangeId"] = filterView.NamedRangeID
+		fields = append(fields, "namedRangeId")
+	}
+	if !reflect.DeepEqual(filterView.SortSpecs, current.SortSpecs) {
+		params["sortSpecs"] = filterView.SortSpecs
+		fields = append(fields, "sortSpecs")
+	}
+	if !reflect.DeepEqual(filterView.Criteria, current.Criteria) {
+		params["criteria"] = filterView.Criteria
+		fields = append(fields, "criteria")
+	}
+	if len(fields) == 0 {
+		return r
+	}
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"updateFilterView": map[string]interface{}{
+			"filter": params,
+			"fields": strings.Join(fields, ","),
+		},
+	})
+	return r
 }
 
 func (r *updateRequest) AppendDimension() {
 
 }
 
-func (r *updateRequest) AddConditionalFormatRule() {
-
+// AddConditionalFormatRule adds a conditional format rule at the index
+func (r *updateRequest) AddConditionalFormatRule(rule ConditionalFormatRule, index int) *updateRequest {
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"addConditionalFormatRule": map[string]interface{}{
+			"rule":  rule,
+			"index": index,
+		},
+	})
+	return r
 }
 
-func (r *updateRequest) UpdateConditionalFormatRule() {
-
+// UpdateConditionalFormatRule replaces the conditional format rule at the index
+func (r *updateRequest) UpdateConditionalFormatRule(sheet *Sheet, index int, rule ConditionalFormatRule) *updateRequest {
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"updateConditionalFormatRule": map[string]interface{}{
+			"sheetId": sheet.Properties.ID,
+			"index":   index,
+			"rule":    rule,
+		},
+	})
+	return r
 }
 
-func (r *updateRequest) DeleteConditionalFormatRule() {
+// MoveConditionalFormatRule moves the conditional format rule at the index to the new index
+func (r *updateRequest) MoveConditionalFormatRule(sheet *Sheet, index, newIndex int) *updateRequest {
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"updateConditionalFormatRule": map[string]interface{}{
+			"sheetId":  sheet.Properties.ID,
+			"index":    index,
+			"newIndex": newIndex,
+		},
+	})
+	return r
+}
 
+// DeleteConditionalFormatRule deletes the conditional format rule at the index
+func (r *updateRequest) DeleteConditionalFormatRule(sheet *Sheet, index int) *updateRequest {
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"deleteConditionalFormatRule": map[string]interface{}{
+			"sheetId": sheet.Properties.ID,
+			"index":   index,
+		},
+	})
+	return r
 }
 
 func (r *updateRequest) SortRange() {
 
 }
 
-func (r *updateRequest) SetDataValidation() {
-
+// SetDataValidation sets a data validation rule to every cell in the range, or clears it if the rule is nil
+func (r *updateRequest) SetDataValidation(gridRange GridRange, rule *DataValidationRule) *updateRequest {
+	params := map[string]interface{}{
+		"range": gridRange,
+	}
+	if rule != nil {
+		params["rule"] = rule
+	}
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"setDataValidation": params,
+	})
+	return r
 }
 
-func (r *updateRequest) SetBasicFilter() {
-
+// SetBasicFilter sets the basic filter of the sheet
+func (r *updateRequest) SetBasicFilter(filter BasicFilter) *updateRequest {
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"setBasicFilter": map[string]interface{}{
+			"filter": filter,
+		},
+	})
+	return r
 }
 
-func (r *updateRequest) AddProtectedRange() {
-
+// AddProtectedRange adds a protected range
+func (r *updateRequest) AddProtectedRange(protectedRange ProtectedRange) *updateRequest {
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"addProtectedRange": map[string]interface{}{
+			"protectedRange": protectedRange,
+		},
+	})
+	return r
 }
 
-func (r *updateRequest) UpdateProtectedRange() {
-
+// UpdateProtectedRange updates the changed fields of the protected range
+func (r *updateRequest) UpdateProtectedRange(current, protectedRange ProtectedRange) *updateRequest {
+	params := map[string]interface{}{
+		"protectedRangeId": current.ProtectedRangeID,
+	}
+	fields := []string{}
+	if !reflect.DeepEqual(protectedRange.Range, current.Range) {
+		params["range"] = protectedRange.Range
+		fields = append(fields, "range")
+	}
+	if protectedRange.NamedRangeID != current.NamedRangeID {
+		params["namedRangeId"] = protectedRange.NamedRangeID
+		fields = append(fields, "namedRangeId")
+	}
+	if protectedRange.Description != current.Description {
+		params["description"] = protectedRange.Description
+		fields = append(fields, "description")
+	}
+	if protectedRange.WarningOnly != current.WarningOnly {
+		params["warningOnly"] = protectedRange.WarningOnly
+		fields = append(fields, "warningOnly")
+	}
+	if !reflect.DeepEqual(protectedRange.UnprotectedRanges, current.UnprotectedRanges) {
+		params["unprotectedRanges"] = protectedRange.UnprotectedRanges
+		fields = append(fields, "unprotectedRanges")
+	}
+	if !reflect.DeepEqual(protectedRange.Editors, current.Editors) {
+		params["editors"] = protectedRange.Editors
+		fields = append(fields, "editors")
+	}
+	if len(fields) == 0 {
+		return r
+	}
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"updateProtectedRange": map[string]interface{}{
+			"protectedRange": params,
+			"fields":         strings.Join(fields, ","),
+		},
+	})
+	return r
 }
 
-func (r *updateRequest) DeleteProtectedRange() {
-
+// DeleteProtectedRange deletes the protected range
+func (r *updateRequest) DeleteProtectedRange(protectedRangeID uint) *updateRequest {
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"deleteProtectedRange": map[string]interface{}{
+			"protectedRangeId": protectedRangeID,
+		},
+	})
+	return r
 }
 
-func (r *updateRequest) AutoResizeDimensions() {
-
+// AutoResizeDimensions resizes dimensions within the range to fit their contents
+func (r *updateRequest) AutoResizeDimensions(dimensionRange DimensionRange) *updateRequest {
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"autoResizeDimensions": map[string]interface{}{
+			"dimensions": dimensionRange,
+		},
+	})
+	return r
 }
 
-func (r *updateRequest) AddChart() {
-
+// AddChart adds a chart
+func (r *updateRequest) AddChart(chart EmbeddedChart) *updateRequest {
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"addChart": map[string]interface{}{
+			"chart": chart,
+		},
+	})
+	return r
 }
 
-func (r *updateRequest) UpdateChartSpec() {
-
+// UpdateChartSpec replaces the specification of the chart
+func (r *updateRequest) UpdateChartSpec(chartID uint, spec ChartSpec) *updateRequest {
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"updateChartSpec": map[string]interface{}{
+			"chartId": chartID,
+			"spec":    spec,
+		},
+	})
+	return r
 }
 
-func (r *updateRequest) UpdateBanding() {
+// UpdateBanding updates the changed fields of the banded range
+func (r *updateRequest) UpdateBanding(current, bandedRange BandedRange) *updateRequest {
+	params := map[string]interface{}{
+		"bandedRangeId": current.BandedRangeID,
+	}
+	fields := []string{}
+	if bandedRange.Range != current.Range {
+		params["range"] = bandedRange.Range
+		fields = append(fields, "range")
+	}
+	if !reflect.DeepEqual(bandedRange.RowProperties, current.RowProperties) {
+		params["rowProperties"] = bandedRange.RowProperties
+		fields = append(fields, "rowProperties")
+	}
+	if !reflect.DeepEqual(bandedRange.ColumnProperties, current.ColumnProperties) {
+		params["columnProperties"] = bandedRange.ColumnProperties
+		fields = append(fields, "columnProperties")
+	}
+	if len(fields) == 0 {
+		return r
+	}
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"updateBanding": map[string]interface{}{
+			"bandedRange": params,
+			"fields":      strings.Join(fields, ","),
+		},
+	})
+	return r
+}
 
+// AddBanding adds a banded range
+func (r *updateRequest) AddBanding(bandedRange BandedRange) *updateRequest {
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"addBanding": map[string]interface{}{
+			"bandedRange": bandedRange,
+		},
+	})
+	return r
 }
 
-func (r *updateRequest) AddBanding() {
+// DeleteBanding deletes the banded range
+func (r *updateRequest) DeleteBanding(bandedRangeID uint) *updateRequest {
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"deleteBanding": map[string]interface{}{
+			"bandedRangeId": bandedRangeID,
+		},
+	})
+	return r
+}
 
+// CreateDeveloperMetadata creates a developer metadata
+func (r *updateRequest) CreateDeveloperMetadata(metadata DeveloperMetadata) *updateRequest {
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"createDeveloperMetadata": map[string]interface{}{
+			"developerMetadata": metadata,
+		},
+	})
+	return r
 }
 
-func (r *updateRequest) DeleteBanding() {
+// UpdateDeveloperMetadata updates the fields of all developer metadata matching the filters
+func (r *updateRequest) UpdateDeveloperMetadata(filters []DataFilter, metadata DeveloperMetadata, fields string) *updateRequest {
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"updateDeveloperMetadata": map[string]interface{}{
+			"dataFilters":       filters,
+			"developerMetadata": metadata,
+			"fields":            fields,
+		},
+	})
+	return r
+}
 
+// DeleteDeveloperMetadata deletes all developer metadata matching the filter
+func (r *updateRequest) DeleteDeveloperMetadata(filter DataFilter) *updateRequest {
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"deleteDeveloperMetadata": map[string]interface{}{
+			"dataFilter": filter,
+		},
+	})
+	return r
 }
@@ -0,0 +1,38 @@
+package spreadsheet
+
+type updateResponse struct {
+	SpreadsheetID string        `json:"spreadsheetId"`
+	Replies       []updateReply `json:"replies"`
+}
+
+// updateReply is a single kind of response to an update request.
+// Only the field of the corresponding request is set.
+type updateReply struct {
+	AddNamedRange *struct {
+		NamedRange NamedRange `json:"namedRange"`
+	} `json:"addNamedRange"`
+	AddProtectedRange *struct {
+		ProtectedRange ProtectedRange `json:"protectedRange"`
+	} `json:"addProtectedRange"`
+	AddFilterView *struct {
+		Filter FilterView `json:"filter"`
+	} `json:"addFilterView"`
+	DuplicateFilterView *struct {
+		Filter FilterView `json:"filter"`
+	} `json:"duplicateFilterView"`
+	AddBanding *struct {
+		BandedRange BandedRange `json:"bandedRange"`
+	} `json:"addBanding"`
+	AddChart *struct {
+		Chart EmbeddedChart `json:"chart"`
+	} `json:"addChart"`
+	CreateDeveloperMetadata *struct {
+		DeveloperMetadata DeveloperMetadata `json:"developerMetadata"`
+	} `json:"createDeveloperMetadata"`
+	UpdateDeveloperMetadata *struct {
+		DeveloperMetadata []DeveloperMetadata `json:"developerMetadata"`
+	} `json:"updateDeveloperMetadata"`
+	DeleteDeveloperMetadata *struct {
+		DeletedDeveloperMetadata []DeveloperMetadata `json:"deletedDeveloperMetadata"`
+	} `json:"deleteDeveloperMetadata"`
+}
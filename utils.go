@@ -1,8 +1,12 @@
 package spreadsheet
 
 import (
+	"encoding/json"
+	"fmt"
 	"math"
+	"reflect"
 	"strconv"
+	"strings"
 )
 
 func numberToLetter(num int) string {
@@ -13,6 +17,50 @@ func numberToLetter(num int) string {
 	return numberToLetter(int((num-1)/26)) + string(byte(65+(num-1)%26))
 }
 
+// The limits of references to cells, which end at XFD1048576.
+const (
+	maxRowNumber    = 1048576
+	maxColumnNumber = 16384
+)
+
+// parseCellRef parses a reference to a cell like "AB12" into zero based indexes.
+// A reference past XFD1048576 is an error.
+func parseCellRef(ref string) (row, column uint, err error) {
+	ref = strings.Replace(ref, "$", "", -1)
+	i := 0
+	for i < len(ref) && ref[i] >= 'A' && ref[i] <= 'Z' && column <= maxColumnNumber {
+		column = column*26 + uint(ref[i]-'A'+1)
+		i++
+	}
+	number, parseErr := strconv.ParseUint(ref[i:], 10, 32)
+	if i == 0 || parseErr != nil || number == 0 {
+		err = fmt.Errorf("invalid cell reference %q", ref)
+		return
+	}
+	if column > maxColumnNumber || number > maxRowNumber {
+		err = fmt.Errorf("cell reference %q is out of range", ref)
+		return
+	}
+	return uint(number) - 1, column - 1, nil
+}
+
+// parseRangeRef parses a reference to a range like "A1:B2" into a GridRange.
+func parseRangeRef(ref string) (gridRange GridRange, err error) {
+	refs := strings.SplitN(ref, ":", 2)
+	if len(refs) == 1 {
+		refs = append(refs, refs[0])
+	}
+	if gridRange.StartRowIndex, gridRange.StartColumnIndex, err = parseCellRef(refs[0]); err != nil {
+		return
+	}
+	if gridRange.EndRowIndex, gridRange.EndColumnIndex, err = parseCellRef(refs[1]); err != nil {
+		return
+	}
+	gridRange.EndRowIndex++
+	gridRange.EndColumnIndex++
+	return
+}
+
 func cellValueType(val string) string {
 	if len(val) == 0 {
 		return "stringValue"
@@ -34,6 +82,11 @@ func cellValueType(val string) string {
 	return "stringValue"
 }
 
+// escapeFormulaString escapes a string to be quoted in a formula.
+func escapeFormulaString(s string) string {
+	return strings.Replace(s, `"`, `""`, -1)
+}
+
 func isNumericFloat(val float64) bool {
 	if math.IsInf(val, 1) || math.IsInf(val, -1) || math.IsNaN(val) {
 		return false
@@ -41,3 +94,15 @@ func isNumericFloat(val float64) bool {
 
 	return true
 }
+
+// deepCopy copies src to dst through JSON so that they share no pointers, slices or maps.
+// dst must be a pointer.
+func deepCopy(dst, src interface{}) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	v := reflect.ValueOf(dst).Elem()
+	v.Set(reflect.Zero(v.Type()))
+	return json.Unmarshal(data, dst)
+}
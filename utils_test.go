@@ -42,9 +42,49 @@ func TestIsNumericFloat(t *testing.T) {
 	assert.False(isNumericFloat(math.NaN()))
 }
 
+func TestDeepCopy(t *testing.T) {
+	assert := assert.New(t)
+	src := ProtectedRange{
+		ProtectedRangeID: 1,
+		Range:            &GridRange{EndRowIndex: 1},
+	}
+	dst := ProtectedRange{Editors: &Editors{Users: []string{"a@example.com"}}}
+	assert.NoError(deepCopy(&dst, src))
+	assert.Equal(src, dst)
+	src.Range.EndRowIndex = 2
+	assert.Equal(uint(1), dst.Range.EndRowIndex)
+}
+
 func BenchmarkNumberToLetter(b *testing.B) {
 	b.ReportAllocs()
 	for i := 0; i < b.N; i++ {
 		_ = numberToLetter(i)
 	}
 }
+
+func TestParseCellRef(t *testing.T) {
+	assert := assert.New(t)
+	row, column, err := parseCellRef("AB12")
+	assert.NoError(err)
+	assert.Equal(uint(11), row)
+	assert.Equal(uint(27), column)
+	row, column, err = parseCellRef("$A$1")
+	assert.NoError(err)
+	assert.Equal(uint(0), row)
+	assert.Equal(uint(0), column)
+	row, column, err = parseCellRef("XFD1048576")
+	assert.NoError(err)
+	assert.Equal(uint(1048575), row)
+	assert.Equal(uint(16383), column)
+	for _, ref := range []string{"", "A", "12", "A0", "a1", "XFE1", "A1048577", "GKGWBYLWRXTLPP1"} {
+		_, _, err = parseCellRef(ref)
+		assert.Error(err, ref)
+	}
+
+	gridRange, err := parseRangeRef("B2:C4")
+	assert.NoError(err)
+	assert.Equal(GridRange{StartRowIndex: 1, EndRowIndex: 4, StartColumnIndex: 1, EndColumnIndex: 3}, gridRange)
+	gridRange, err = parseRangeRef("C3")
+	assert.NoError(err)
+	assert.Equal(GridRange{StartRowIndex: 2, EndRowIndex: 3, StartColumnIndex: 2, EndColumnIndex: 3}, gridRange)
+}
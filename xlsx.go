@@ -0,0 +1,675 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	xlsxHeader          = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n"
+	xlsxMainNS          = "http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+	xlsxRelationshipsNS = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+	xlsxPackageRelNS    = "http://schemas.openxmlformats.org/package/2006/relationships"
+
+	// xlsxPixelsPerCharacter converts widths in characters of XLSX to pixels.
+	xlsxPixelsPerCharacter = 7
+	// xlsxMaxCells is the limit of cells in a spreadsheet of Google Sheets.
+	xlsxMaxCells = 10000000
+)
+
+// WriteXLSX writes the spreadsheet to w as an XLSX file with the values, formulas,
+// basic formats, merges, column widths and frozen rows and columns of the sheets.
+func (spreadsheet *Spreadsheet) WriteXLSX(w io.Writer) error {
+	sheets := make([]*Sheet, len(spreadsheet.Sheets))
+	for i := range spreadsheet.Sheets {
+		sheets[i] = &spreadsheet.Sheets[i]
+	}
+	sort.SliceStable(sheets, func(i, j int) bool {
+		return sheets[i].Properties.Index < sheets[j].Properties.Index
+	})
+
+	var contentTypes, workbook, workbookRels bytes.Buffer
+	contentTypes.WriteString(xlsxHeader)
+	contentTypes.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	contentTypes.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	contentTypes.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	contentTypes.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	contentTypes.WriteString(`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	workbook.WriteString(xlsxHeader)
+	fmt.Fprintf(&workbook, `<workbook xmlns="%s" xmlns:r="%s"><sheets>`, xlsxMainNS, xlsxRelationshipsNS)
+	workbookRels.WriteString(xlsxHeader)
+	fmt.Fprintf(&workbookRels, `<Relationships xmlns="%s">`, xlsxPackageRelNS)
+	fmt.Fprintf(&workbookRels, `<Relationship Id="rId0" Type="%s/styles" Target="styles.xml"/>`, xlsxRelationshipsNS)
+
+	z := zip.NewWriter(w)
+	styles := &xlsxStyles{index: map[string]int{}}
+	sheetNames := map[string]bool{}
+	for i, sheet := range sheets {
+		name := fmt.Sprintf("sheet%d.xml", i+1)
+		if err := writeZipFile(z, "xl/worksheets/"+name, sheet.xlsxWorksheet(styles)); err != nil {
+			return err
+		}
+		fmt.Fprintf(&contentTypes, `<Override PartName="/xl/worksheets/%s" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, name)
+		state := ""
+		if sheet.Properties.Hidden {
+			state = ` state="hidden"`
+		}
+		fmt.Fprintf(&workbook, `<sheet name="%s" sheetId="%d"%s r:id="rId%d"/>`, xmlEscape(xlsxSheetName(sheet.Properties.Title, i, sheetNames)), i+1, state, i+1)
+		fmt.Fprintf(&workbookRels, `<Relationship Id="rId%d" Type="%s/worksheet" Target="worksheets/%s"/>`, i+1, xlsxRelationshipsNS, name)
+	}
+	contentTypes.WriteString(`</Types>`)
+	workbook.WriteString(`</sheets></workbook>`)
+	workbookRels.WriteString(`</Relationships>`)
+
+	rootRels := xlsxHeader + fmt.Sprintf(`<Relationships xmlns="%s"><Relationship Id="rId1" Type="%s/officeDocument" Target="xl/workbook.xml"/></Relationships>`, xlsxPackageRelNS, xlsxRelationshipsNS)
+	for _, file := range []struct {
+		name string
+		data []byte
+	}{
+		{"[Content_Types].xml", contentTypes.Bytes()},
+		{"_rels/.rels", []byte(rootRels)},
+		{"xl/workbook.xml", workbook.Bytes()},
+		{"xl/_rels/workbook.xml.rels", workbookRels.Bytes()},
+		{"xl/styles.xml", styles.xml()},
+	} {
+		if err := writeZipFile(z, file.name, file.data); err != nil {
+			return err
+		}
+	}
+	return z.Close()
+}
+
+func writeZipFile(z *zip.Writer, name string, data []byte) error {
+	f, err := z.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	return err
+}
+
+// xlsxSheetName makes the title a valid name of a worksheet, which is not in the used names.
+// Names are unique regardless of case, and a name which is already used gets a suffix like " (2)".
+func xlsxSheetName(title string, index int, used map[string]bool) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '_'
+		}
+		return r
+	}, title)
+	if runes := []rune(name); len(runes) > 31 {
+		name = string(runes[:31])
+	}
+	if name == "" {
+		name = fmt.Sprintf("Sheet%d", index+1)
+	}
+	base := []rune(name)
+	for n := 2; used[strings.ToLower(name)]; n++ {
+		suffix := fmt.Sprintf(" (%d)", n)
+		if len(base)+len(suffix) > 31 {
+			base = base[:31-len(suffix)]
+		}
+		name = string(base) + suffix
+	}
+	used[strings.ToLower(name)] = true
+	return name
+}
+
+func (sheet *Sheet) xlsxWorksheet(styles *xlsxStyles) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(xlsxHeader)
+	fmt.Fprintf(&buf, `<worksheet xmlns="%s" xmlns:r="%s">`, xlsxMainNS, xlsxRelationshipsNS)
+
+	buf.WriteString(`<sheetViews><sheetView workbookViewId="0"`)
+	if sheet.Properties.RightToLeft {
+		buf.WriteString(` rightToLeft="1"`)
+	}
+	buf.WriteString(`>`)
+	rows, columns := sheet.Properties.GridProperties.FrozenRowCount, sheet.Properties.GridProperties.FrozenColumnCount
+	if rows > 0 || columns > 0 {
+		pane := "bottomRight"
+		if columns == 0 {
+			pane = "bottomLeft"
+		} else if rows == 0 {
+			pane = "topRight"
+		}
+		buf.WriteString(`<pane`)
+		if columns > 0 {
+			fmt.Fprintf(&buf, ` xSplit="%d"`, columns)
+		}
+		if rows > 0 {
+			fmt.Fprintf(&buf, ` ySplit="%d"`, rows)
+		}
+		fmt.Fprintf(&buf, ` topLeftCell="%s%d" activePane="%s" state="frozen"/>`, numberToLetter(int(columns)+1), rows+1, pane)
+	}
+	buf.WriteString(`</sheetView></sheetViews>`)
+
+	var cols bytes.Buffer
+	for i, meta := range sheet.columnMetadata {
+		if meta.PixelSize == 0 && !meta.HiddenByUser {
+			continue
+		}
+		fmt.Fprintf(&cols, `<col min="%d" max="%d"`, i+1, i+1)
+		if meta.PixelSize > 0 {
+			fmt.Fprintf(&cols, ` width="%s" customWidth="1"`, strconv.FormatFloat(math.Round(float64(meta.PixelSize)/xlsxPixelsPerCharacter*100)/100, 'f', -1, 64))
+		}
+		if meta.HiddenByUser {
+			cols.WriteString(` hidden="1"`)
+		}
+		cols.WriteString(`/>`)
+	}
+	if cols.Len() > 0 {
+		buf.WriteString(`<cols>`)
+		buf.Write(cols.Bytes())
+		buf.WriteString(`</cols>`)
+	}
+
+	buf.WriteString(`<sheetData>`)
+	for i, row := range sheet.Rows {
+		var cells bytes.Buffer
+		for j := range row {
+			row[j].writeXLSX(&cells, styles.id(row[j].format))
+		}
+		if cells.Len() > 0 {
+			fmt.Fprintf(&buf, `<row r="%d">`, i+1)
+			buf.Write(cells.Bytes())
+			buf.WriteString(`</row>`)
+		}
+	}
+	buf.WriteString(`</sheetData>`)
+
+	if len(sheet.Merges) > 0 {
+		fmt.Fprintf(&buf, `<mergeCells count="%d">`, len(sheet.Merges))
+		for _, merge := range sheet.Merges {
+			fmt.Fprintf(&buf, `<mergeCell ref="%s%d:%s%d"/>`,
+				numberToLetter(int(merge.StartColumnIndex)+1), merge.StartRowIndex+1,
+				numberToLetter(int(merge.EndColumnIndex)), merge.EndRowIndex)
+		}
+		buf.WriteString(`</mergeCells>`)
+	}
+	buf.WriteString(`</worksheet>`)
+	return buf.Bytes()
+}
+
+func (cell *Cell) writeXLSX(buf *bytes.Buffer, style int) {
+	raw := cell.rawValue
+	if raw.Kind() == ValueKindNone && cell.Value != "" {
+		raw = newExtendedValue(cell.Value)
+	}
+	if raw.Kind() == ValueKindNone && style == 0 {
+		return
+	}
+	fmt.Fprintf(buf, `<c r="%s"`, cell.Pos())
+	if style > 0 {
+		fmt.Fprintf(buf, ` s="%d"`, style)
+	}
+	switch raw.Kind() {
+	case ValueKindFormula:
+		t, v := xlsxValue(cell.effectiveValue)
+		if t == "inlineStr" {
+			t = "str"
+		}
+		if t != "" {
+			fmt.Fprintf(buf, ` t="%s"`, t)
+		}
+		fmt.Fprintf(buf, `><f>%s</f>`, xmlEscape(strings.TrimPrefix(raw.FormulaValue, "=")))
+		if cell.effectiveValue.Kind() != ValueKindNone {
+			fmt.Fprintf(buf, `<v>%s</v>`, xmlEscape(v))
+		}
+		buf.WriteString(`</c>`)
+	case ValueKindString:
+		fmt.Fprintf(buf, ` t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, xmlEscape(raw.StringValue))
+	case ValueKindNone:
+		buf.WriteString(`/>`)
+	default:
+		t, v := xlsxValue(raw)
+		if t != "" {
+			fmt.Fprintf(buf, ` t="%s"`, t)
+		}
+		fmt.Fprintf(buf, `><v>%s</v></c>`, xmlEscape(v))
+	}
+}
+
+// xlsxValue returns the type and the value of a cell in XLSX.
+func xlsxValue(v ExtendedValue) (t, value string) {
+	switch v.Kind() {
+	case ValueKindString:
+		return "inlineStr", v.StringValue
+	case ValueKindBool:
+		if v.BoolValue {
+			return "b", "1"
+		}
+		return "b", "0"
+	case ValueKindError:
+		return "e", v.ErrorValue.Code()
+	}
+	return "", v.text()
+}
+
+func xmlEscape(s string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Type   string `xml:"Type,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+// target returns the path of the first relationship of the type relative to the part at base.
+func (rels *xlsxRelationships) target(base, idOrType string) string {
+	for _, rel := range rels.Relationships {
+		if rel.ID == idOrType || strings.HasSuffix(rel.Type, "/"+idOrType) {
+			if strings.HasPrefix(rel.Target, "/") {
+				return strings.TrimPrefix(rel.Target, "/")
+			}
+			return path.Join(path.Dir(base), rel.Target)
+		}
+	}
+	return ""
+}
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		Name  string `xml:"name,attr"`
+		State string `xml:"state,attr"`
+		RID   string `xml:"id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t *xlsxText) text() string {
+	s := t.T
+	for _, run := range t.Runs {
+		s += run.T
+	}
+	return s
+}
+
+type xlsxWorksheet struct {
+	SheetViews []struct {
+		RightToLeft bool `xml:"rightToLeft,attr"`
+		Pane        *struct {
+			XSplit float64 `xml:"xSplit,attr"`
+			YSplit float64 `xml:"ySplit,attr"`
+			State  string  `xml:"state,attr"`
+		} `xml:"pane"`
+	} `xml:"sheetViews>sheetView"`
+	Cols []struct {
+		Min    int     `xml:"min,attr"`
+		Max    int     `xml:"max,attr"`
+		Width  float64 `xml:"width,attr"`
+		Hidden bool    `xml:"hidden,attr"`
+	} `xml:"cols>col"`
+	Rows []struct {
+		R     int `xml:"r,attr"`
+		Cells []struct {
+			R string `xml:"r,attr"`
+			S int    `xml:"s,attr"`
+			T string `xml:"t,attr"`
+			F *struct {
+				Text string `xml:",chardata"`
+				T    string `xml:"t,attr"`
+				SI   string `xml:"si,attr"`
+			} `xml:"f"`
+			V  string    `xml:"v"`
+			IS *xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+	MergeCells []struct {
+		Ref string `xml:"ref,attr"`
+	} `xml:"mergeCells>mergeCell"`
+}
+
+// ReadXLSX reads an XLSX file into a spreadsheet which is not attached to any service.
+// The sheets have the IDs from 1 in order, and can be created with Service.CreateSpreadsheet
+// after giving the spreadsheet a title.
+// Formulas shared between cells are read with their relative references shifted to each cell.
+// Cells which only have a style are dropped past the last cell with a value,
+// and a workbook with more cells than a spreadsheet can have is an error.
+func ReadXLSX(r io.ReaderAt, size int64) (spreadsheet Spreadsheet, err error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return
+	}
+	files := map[string]*zip.File{}
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+	read := func(name string, v interface{}) error {
+		f, ok := files[name]
+		if !ok {
+			return fmt.Errorf("%s not found in the xlsx file", name)
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+		defer rc.Close()
+		data, err := ioutil.ReadAll(rc)
+		if err != nil {
+			return err
+		}
+		return xml.Unmarshal(data, v)
+	}
+
+	var rootRels, workbookRels xlsxRelationships
+	if err = read("_rels/.rels", &rootRels); err != nil {
+		return
+	}
+	workbookPath := rootRels.target("", "officeDocument")
+	if workbookPath == "" {
+		err = errors.New("workbook not found in the xlsx file")
+		return
+	}
+	var workbook xlsxWorkbook
+	if err = read(workbookPath, &workbook); err != nil {
+		return
+	}
+	if err = read(path.Join(path.Dir(workbookPath), "_rels", path.Base(workbookPath)+".rels"), &workbookRels); err != nil {
+		return
+	}
+	var sharedStrings struct {
+		Items []xlsxText `xml:"si"`
+	}
+	if name := workbookRels.target(workbookPath, "sharedStrings"); name != "" {
+		if err = read(name, &sharedStrings); err != nil {
+			return
+		}
+	}
+	var styles xlsxStyleSheet
+	if name := workbookRels.target(workbookPath, "styles"); name != "" {
+		if err = read(name, &styles); err != nil {
+			return
+		}
+	}
+
+	var total uint64
+	// the sheets are made in place, so that their cells keep pointing to them
+	spreadsheet.Sheets = make([]Sheet, len(workbook.Sheets))
+	for i, s := range workbook.Sheets {
+		var worksheet xlsxWorksheet
+		if err = read(workbookRels.target(workbookPath, s.RID), &worksheet); err != nil {
+			return
+		}
+		sheet := &spreadsheet.Sheets[i]
+		*sheet = Sheet{
+			Properties: SheetProperties{
+				ID:        uint(i + 1),
+				Title:     s.Name,
+				Index:     uint(i),
+				SheetType: "GRID",
+				Hidden:    s.State == "hidden" || s.State == "veryHidden",
+			},
+		}
+		cells := []Cell{}
+		styledCells := []Cell{}
+		sharedFormulas := map[string]Cell{}
+		var maxRow, maxColumn uint
+		for rowNum, row := range worksheet.Rows {
+			r := uint(rowNum)
+			if row.R > 0 {
+				r = uint(row.R - 1)
+			}
+			if r >= maxRowNumber {
+				err = fmt.Errorf("row %d is out of range", r+1)
+				return
+			}
+			for columnNum, c := range row.Cells {
+				cell := Cell{Row: r, Column: uint(columnNum), format: styles.format(c.S), sheet: sheet}
+				if c.R != "" {
+					if cell.Row, cell.Column, err = parseCellRef(c.R); err != nil {
+						return
+					}
+				}
+				var value ExtendedValue
+				switch c.T {
+				case "s":
+					index, _ := strconv.Atoi(c.V)
+					if index < len(sharedStrings.Items) {
+						value = ExtendedValue{StringValue: sharedStrings.Items[index].text(), kind: ValueKindString}
+					}
+				case "inlineStr":
+					if c.IS != nil {
+						value = ExtendedValue{StringValue: c.IS.text(), kind: ValueKindString}
+					}
+				case "str":
+					value = ExtendedValue{StringValue: c.V, kind: ValueKindString}
+				case "b":
+					value = ExtendedValue{BoolValue: c.V == "1" || c.V == "true", kind: ValueKindBool}
+				case "e":
+					value = ExtendedValue{ErrorValue: ErrorValue{Type: errorTypeByCode(c.V)}, kind: ValueKindError}
+				default:
+					if c.V != "" {
+						if number, parseErr := strconv.ParseFloat(c.V, 64); parseErr == nil {
+							value = ExtendedValue{NumberValue: number, kind: ValueKindNumber}
+						}
+					}
+				}
+				cell.effectiveValue = value
+				cell.rawValue = value
+				formula := ""
+				if c.F != nil {
+					formula = c.F.Text
+					// the first cell of a shared formula has its text, which the others shift
+					if c.F.T == "shared" && formula != "" {
+						sharedFormulas[c.F.SI] = Cell{Row: cell.Row, Column: cell.Column, Value: formula}
+					} else if c.F.T == "shared" {
+						first, ok := sharedFormulas[c.F.SI]
+						if !ok {
+							err = fmt.Errorf("shared formula %q of cell %s%d is not defined", c.F.SI, numberToLetter(int(cell.Column)+1), cell.Row+1)
+							return
+						}
+						formula = shiftFormula(first.Value, int(cell.Row)-int(first.Row), int(cell.Column)-int(first.Column))
+					}
+				}
+				if formula != "" {
+					cell.rawValue = ExtendedValue{FormulaValue: "=" + formula, kind: ValueKindFormula}
+				} else if value.Kind() == ValueKindError {
+					cell.rawValue = ExtendedValue{}
+				}
+				cell.Value = value.text()
+				if value.Kind() == ValueKindNone && cell.rawValue.Kind() == ValueKindNone {
+					// Excel often writes styled cells far beyond the data, which are kept only within it
+					if cell.format != nil {
+						styledCells = append(styledCells, cell)
+					}
+					continue
+				}
+				if cell.Row > maxRow {
+					maxRow = cell.Row
+				}
+				if cell.Column > maxColumn {
+					maxColumn = cell.Column
+				}
+				cells = append(cells, cell)
+			}
+		}
+		for _, cell := range styledCells {
+			if cell.Row <= maxRow && cell.Column <= maxColumn {
+				cells = append(cells, cell)
+			}
+		}
+		if total += (uint64(maxRow) + 1) * (uint64(maxColumn) + 1); total > xlsxMaxCells {
+			err = fmt.Errorf("workbook has more than %d cells", xlsxMaxCells)
+			return
+		}
+		sheet.Rows, sheet.Columns = newCells(maxRow, maxColumn)
+		for _, cell := range cells {
+			sheet.Rows[cell.Row][cell.Column] = cell
+			sheet.Columns[cell.Column][cell.Row] = cell
+		}
+
+		for _, merge := range worksheet.MergeCells {
+			var gridRange GridRange
+			if gridRange, err = parseRangeRef(merge.Ref); err != nil {
+				return
+			}
+			gridRange.SheetID = sheet.Properties.ID
+			sheet.Merges = append(sheet.Merges, gridRange)
+		}
+
+		grid := &sheet.Properties.GridProperties
+		grid.RowCount = maxRow + 1
+		if grid.RowCount < 1000 {
+			grid.RowCount = 1000
+		}
+		grid.ColumnCount = maxColumn + 1
+		if grid.ColumnCount < 26 {
+			grid.ColumnCount = 26
+		}
+		for _, view := range worksheet.SheetViews {
+			sheet.Properties.RightToLeft = view.RightToLeft
+			if view.Pane != nil && strings.HasPrefix(view.Pane.State, "frozen") {
+				grid.FrozenRowCount = uint(view.Pane.YSplit)
+				grid.FrozenColumnCount = uint(view.Pane.XSplit)
+			}
+		}
+		sheet.columnMetadata = []DimensionProperties{}
+		for _, col := range worksheet.Cols {
+			for c := col.Min; c <= col.Max && c <= int(grid.ColumnCount); c++ {
+				sheet.columnMetadata = setDimensionProperties(sheet.columnMetadata, uint(c-1), &DimensionProperties{
+					PixelSize:    uint(math.Round(col.Width * xlsxPixelsPerCharacter)),
+					HiddenByUser: col.Hidden,
+				})
+			}
+		}
+		sheet.rowMetadata = []DimensionProperties{}
+		sheet.modifiedCells = []*Cell{}
+		sheet.newMaxRow = grid.RowCount
+		sheet.newMaxColumn = grid.ColumnCount
+	}
+	for i := range spreadsheet.Sheets {
+		spreadsheet.Sheets[i].Spreadsheet = &spreadsheet
+	}
+	return
+}
+
+// xlsxReferences matches references to cells, columns and rows in formulas.
+var xlsxReferences = regexp.MustCompile(`\$?[A-Z]{1,3}\$?[0-9]+|\$?[A-Z]{1,3}:\$?[A-Z]{1,3}|\$?[0-9]+:\$?[0-9]+`)
+
+// shiftFormula moves the relative references of the formula by the rows and columns,
+// as the formula is copied to another cell. Strings and quoted sheet names are left as they are.
+func shiftFormula(formula string, rows, columns int) string {
+	var buf strings.Builder
+	start := 0
+	var quote byte
+	for i := 0; i <= len(formula); i++ {
+		if i == len(formula) {
+			if quote == 0 {
+				buf.WriteString(shiftReferences(formula[start:], rows, columns))
+			} else {
+				buf.WriteString(formula[start:])
+			}
+			break
+		}
+		switch c := formula[i]; {
+		case quote != 0 && c == quote:
+			buf.WriteString(formula[start : i+1])
+			start, quote = i+1, 0
+		case quote == 0 && (c == '"' || c == '\''):
+			buf.WriteString(shiftReferences(formula[start:i], rows, columns))
+			start, quote = i, c
+		}
+	}
+	return buf.String()
+}
+
+// shiftReferences moves the relative references in a part of a formula without quotes.
+func shiftReferences(s string, rows, columns int) string {
+	isName := func(c byte) bool {
+		return c == '_' || c == '.' || c >= '0' && c <= '9' || c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z'
+	}
+	var buf strings.Builder
+	last := 0
+	for _, m := range xlsxReferences.FindAllStringIndex(s, -1) {
+		// skip the parts of names, functions and sheet names
+		if m[0] > 0 && isName(s[m[0]-1]) || m[1] < len(s) && (isName(s[m[1]]) || s[m[1]] == '(' || s[m[1]] == '!') {
+			continue
+		}
+		buf.WriteString(s[last:m[0]])
+		buf.WriteString(shiftReference(s[m[0]:m[1]], rows, columns))
+		last = m[1]
+	}
+	buf.WriteString(s[last:])
+	return buf.String()
+}
+
+// shiftReference moves a reference like "A$1" or "B:C", which is "#REF!" when moved off the grid.
+func shiftReference(ref string, rows, columns int) string {
+	parts := strings.Split(ref, ":")
+	for i, part := range parts {
+		absoluteColumn, absoluteRow := false, false
+		j := 0
+		if j < len(part) && part[j] == '$' {
+			absoluteColumn = true
+			j++
+		}
+		column := 0
+		for ; j < len(part) && part[j] >= 'A' && part[j] <= 'Z'; j++ {
+			column = column*26 + int(part[j]-'A'+1)
+		}
+		if column == 0 {
+			absoluteColumn, absoluteRow = false, absoluteColumn
+		} else if j < len(part) && part[j] == '$' {
+			absoluteRow = true
+			j++
+		}
+		row, _ := strconv.Atoi(part[j:])
+		if column > 0 && !absoluteColumn {
+			if column += columns; column < 1 || column > maxColumnNumber {
+				return "#REF!"
+			}
+		}
+		if row > 0 && !absoluteRow {
+			if row += rows; row < 1 || row > maxRowNumber {
+				return "#REF!"
+			}
+		}
+		shifted := ""
+		if column > 0 {
+			if absoluteColumn {
+				shifted += "$"
+			}
+			shifted += numberToLetter(column)
+		}
+		if row > 0 {
+			if absoluteRow {
+				shifted += "$"
+			}
+			shifted += strconv.Itoa(row)
+		}
+		parts[i] = shifted
+	}
+	return strings.Join(parts, ":")
+}
+
+func errorTypeByCode(code string) string {
+	for t, c := range errorCodes {
+		if c == code {
+			return t
+		}
+	}
+	return ErrorTypeError
+}
@@ -0,0 +1,356 @@
+package spreadsheet
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// xlsxNumberFormats is the built-in number formats of XLSX by their IDs.
+var xlsxNumberFormats = map[int]NumberFormat{
+	1:  {Type: NumberFormatNumber, Pattern: "0"},
+	2:  {Type: NumberFormatNumber, Pattern: "0.00"},
+	3:  {Type: NumberFormatNumber, Pattern: "#,##0"},
+	4:  {Type: NumberFormatNumber, Pattern: "#,##0.00"},
+	9:  {Type: NumberFormatPercent, Pattern: "0%"},
+	10: {Type: NumberFormatPercent, Pattern: "0.00%"},
+	11: {Type: NumberFormatScientific, Pattern: "0.00E+00"},
+	14: {Type: NumberFormatDate},
+	15: {Type: NumberFormatDate, Pattern: "d-mmm-yy"},
+	16: {Type: NumberFormatDate, Pattern: "d-mmm"},
+	17: {Type: NumberFormatDate, Pattern: "mmm-yy"},
+	18: {Type: NumberFormatTime, Pattern: "h:mm AM/PM"},
+	19: {Type: NumberFormatTime, Pattern: "h:mm:ss AM/PM"},
+	20: {Type: NumberFormatTime, Pattern: "h:mm"},
+	21: {Type: NumberFormatTime},
+	22: {Type: NumberFormatDateTime},
+	45: {Type: NumberFormatTime, Pattern: "mm:ss"},
+	46: {Type: NumberFormatTime, Pattern: "[h]:mm:ss"},
+	47: {Type: NumberFormatTime, Pattern: "mm:ss.0"},
+	49: {Type: NumberFormatText},
+}
+
+// xlsxDefaultNumberFormats is the number formats of XLSX for the types without patterns.
+var xlsxDefaultNumberFormats = map[string]int{
+	NumberFormatNumber:     4,
+	NumberFormatPercent:    10,
+	NumberFormatScientific: 11,
+	NumberFormatDate:       14,
+	NumberFormatTime:       21,
+	NumberFormatDateTime:   22,
+	NumberFormatText:       49,
+}
+
+var xlsxHorizontalAlignments = map[string]string{
+	"LEFT":   "left",
+	"CENTER": "center",
+	"RIGHT":  "right",
+}
+
+var xlsxVerticalAlignments = map[string]string{
+	"TOP":    "top",
+	"MIDDLE": "center",
+	"BOTTOM": "bottom",
+}
+
+// xlsxStyles collects the cell formats to be written as the styles of XLSX.
+type xlsxStyles struct {
+	formats []CellFormat
+	index   map[string]int
+}
+
+// id returns the index of the style of the format, which is zero for no format.
+func (s *xlsxStyles) id(format *CellFormat) int {
+	if format == nil {
+		return 0
+	}
+	key, _ := json.Marshal(format)
+	if id, ok := s.index[string(key)]; ok {
+		return id
+	}
+	s.formats = append(s.formats, *format)
+	s.index[string(key)] = len(s.formats)
+	return len(s.formats)
+}
+
+func (s *xlsxStyles) xml() []byte {
+	var numFmts, fonts, fills, xfs bytes.Buffer
+	numFmtIDs := map[string]int{}
+	fontCount, fillCount := 1, 2
+	fonts.WriteString(`<font><sz val="11"/><name val="Calibri"/></font>`)
+	fills.WriteString(`<fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill>`)
+	xfs.WriteString(`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>`)
+	for _, format := range s.formats {
+		numFmtID, fontID, fillID := 0, 0, 0
+		if f := format.NumberFormat; f != nil {
+			numFmtID = xlsxDefaultNumberFormats[f.Type]
+			if f.Pattern != "" {
+				numFmtID = 0
+				for id, builtin := range xlsxNumberFormats {
+					if builtin == *f {
+						numFmtID = id
+					}
+				}
+			}
+			if numFmtID == 0 && f.Pattern != "" {
+				id, ok := numFmtIDs[f.Pattern]
+				if !ok {
+					id = 164 + len(numFmtIDs)
+					numFmtIDs[f.Pattern] = id
+					fmt.Fprintf(&numFmts, `<numFmt numFmtId="%d" formatCode="%s"/>`, id, xmlEscape(f.Pattern))
+				}
+				numFmtID = id
+			}
+		}
+		if t := format.TextFormat; t != nil {
+			fonts.WriteString(`<font>`)
+			for _, flag := range []struct {
+				on  bool
+				tag string
+			}{{t.Bold, "b"}, {t.Italic, "i"}, {t.Strikethrough, "strike"}, {t.Underline, "u"}} {
+				if flag.on {
+					fmt.Fprintf(&fonts, `<%s/>`, flag.tag)
+				}
+			}
+			size := t.FontSize
+			if size == 0 {
+				size = 11
+			}
+			fmt.Fprintf(&fonts, `<sz val="%d"/>`, size)
+			if t.ForegroundColor != nil {
+				fmt.Fprintf(&fonts, `<color rgb="%s"/>`, xlsxColor(t.ForegroundColor))
+			}
+			family := t.FontFamily
+			if family == "" {
+				family = "Calibri"
+			}
+			fmt.Fprintf(&fonts, `<name val="%s"/></font>`, xmlEscape(family))
+			fontID = fontCount
+			fontCount++
+		}
+		if format.BackgroundColor != nil {
+			fmt.Fprintf(&fills, `<fill><patternFill patternType="solid"><fgColor rgb="%s"/></patternFill></fill>`, xlsxColor(format.BackgroundColor))
+			fillID = fillCount
+			fillCount++
+		}
+		fmt.Fprintf(&xfs, `<xf numFmtId="%d" fontId="%d" fillId="%d" borderId="0" xfId="0"`, numFmtID, fontID, fillID)
+		if numFmtID > 0 {
+			xfs.WriteString(` applyNumberFormat="1"`)
+		}
+		if fontID > 0 {
+			xfs.WriteString(` applyFont="1"`)
+		}
+		if fillID > 0 {
+			xfs.WriteString(` applyFill="1"`)
+		}
+		var alignment bytes.Buffer
+		if h, ok := xlsxHorizontalAlignments[format.HorizontalAlignment]; ok {
+			fmt.Fprintf(&alignment, ` horizontal="%s"`, h)
+		}
+		if v, ok := xlsxVerticalAlignments[format.VerticalAlignment]; ok {
+			fmt.Fprintf(&alignment, ` vertical="%s"`, v)
+		}
+		if format.WrapStrategy == "WRAP" {
+			alignment.WriteString(` wrapText="1"`)
+		}
+		if alignment.Len() > 0 {
+			fmt.Fprintf(&xfs, ` applyAlignment="1"><alignment%s/></xf>`, alignment.String())
+		} else {
+			xfs.WriteString(`/>`)
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xlsxHeader)
+	fmt.Fprintf(&buf, `<styleSheet xmlns="%s">`, xlsxMainNS)
+	if len(numFmtIDs) > 0 {
+		fmt.Fprintf(&buf, `<numFmts count="%d">%s</numFmts>`, len(numFmtIDs), numFmts.String())
+	}
+	fmt.Fprintf(&buf, `<fonts count="%d">%s</fonts>`, fontCount, fonts.String())
+	fmt.Fprintf(&buf, `<fills count="%d">%s</fills>`, fillCount, fills.String())
+	buf.WriteString(`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>`)
+	buf.WriteString(`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>`)
+	fmt.Fprintf(&buf, `<cellXfs count="%d">%s</cellXfs>`, len(s.formats)+1, xfs.String())
+	buf.WriteString(`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>`)
+	buf.WriteString(`</styleSheet>`)
+	return buf.Bytes()
+}
+
+// xlsxColor returns the color as ARGB in hex.
+func xlsxColor(c *Color) string {
+	component := func(v float32) int {
+		return int(math.Round(float64(v) * 255))
+	}
+	return fmt.Sprintf("FF%02X%02X%02X", component(c.Red), component(c.Green), component(c.Blue))
+}
+
+type xlsxAttr struct {
+	Val string `xml:"val,attr"`
+}
+
+// on tells whether a flag like <b/> is set.
+func (v *xlsxAttr) on() bool {
+	return v != nil && v.Val != "0" && v.Val != "false" && v.Val != "none"
+}
+
+type xlsxColorValue struct {
+	RGB string `xml:"rgb,attr"`
+}
+
+// color parses the color in ARGB or RGB hex.
+func (v *xlsxColorValue) color() *Color {
+	if v == nil || (len(v.RGB) != 6 && len(v.RGB) != 8) {
+		return nil
+	}
+	rgb, err := strconv.ParseUint(v.RGB[len(v.RGB)-6:], 16, 32)
+	if err != nil {
+		return nil
+	}
+	return &Color{
+		Red:   float32(rgb>>16&0xff) / 255,
+		Green: float32(rgb>>8&0xff) / 255,
+		Blue:  float32(rgb&0xff) / 255,
+	}
+}
+
+type xlsxStyleSheet struct {
+	NumFmts []struct {
+		ID   int    `xml:"numFmtId,attr"`
+		Code string `xml:"formatCode,attr"`
+	} `xml:"numFmts>numFmt"`
+	Fonts []struct {
+		B      *xlsxAttr       `xml:"b"`
+		I      *xlsxAttr       `xml:"i"`
+		Strike *xlsxAttr       `xml:"strike"`
+		U      *xlsxAttr       `xml:"u"`
+		Sz     *xlsxAttr       `xml:"sz"`
+		Color  *xlsxColorValue `xml:"color"`
+		Name   *xlsxAttr       `xml:"name"`
+	} `xml:"fonts>font"`
+	Fills []struct {
+		PatternFill struct {
+			PatternType string          `xml:"patternType,attr"`
+			FgColor     *xlsxColorValue `xml:"fgColor"`
+		} `xml:"patternFill"`
+	} `xml:"fills>fill"`
+	CellXfs []struct {
+		NumFmtID  int `xml:"numFmtId,attr"`
+		FontID    int `xml:"fontId,attr"`
+		FillID    int `xml:"fillId,attr"`
+		Alignment *struct {
+			Horizontal string `xml:"horizontal,attr"`
+			Vertical   string `xml:"vertical,attr"`
+			WrapText   bool   `xml:"wrapText,attr"`
+		} `xml:"alignment"`
+	} `xml:"cellXfs>xf"`
+}
+
+// format returns the cell format of the style, or nil if the style has no format.
+func (s *xlsxStyleSheet) format(id int) *CellFormat {
+	if id <= 0 || id >= len(s.CellXfs) {
+		return nil
+	}
+	xf := s.CellXfs[id]
+	format := CellFormat{}
+	empty := true
+	if f, ok := xlsxNumberFormats[xf.NumFmtID]; ok {
+		format.NumberFormat = &f
+	}
+	for _, numFmt := range s.NumFmts {
+		if numFmt.ID == xf.NumFmtID {
+			format.NumberFormat = &NumberFormat{Type: numberFormatType(numFmt.Code), Pattern: numFmt.Code}
+		}
+	}
+	empty = empty && format.NumberFormat == nil
+	if xf.FontID > 0 && xf.FontID < len(s.Fonts) {
+		font := s.Fonts[xf.FontID]
+		t := &TextFormat{
+			Bold:            font.B.on(),
+			Italic:          font.I.on(),
+			Strikethrough:   font.Strike.on(),
+			Underline:       font.U.on(),
+			ForegroundColor: font.Color.color(),
+		}
+		if font.Sz != nil {
+			if size, err := strconv.ParseFloat(font.Sz.Val, 64); err == nil {
+				t.FontSize = uint(math.Round(size))
+			}
+		}
+		if font.Name != nil {
+			t.FontFamily = font.Name.Val
+		}
+		format.TextFormat = t
+		empty = false
+	}
+	if xf.FillID >= 2 && xf.FillID < len(s.Fills) {
+		fill := s.Fills[xf.FillID].PatternFill
+		if fill.PatternType == "solid" {
+			format.BackgroundColor = fill.FgColor.color()
+			empty = empty && format.BackgroundColor == nil
+		}
+	}
+	if a := xf.Alignment; a != nil {
+		for k, v := range xlsxHorizontalAlignments {
+			if v == a.Horizontal {
+				format.HorizontalAlignment = k
+			}
+		}
+		for k, v := range xlsxVerticalAlignments {
+			if v == a.Vertical {
+				format.VerticalAlignment = k
+			}
+		}
+		if a.WrapText {
+			format.WrapStrategy = "WRAP"
+		}
+		empty = empty && format.HorizontalAlignment == "" && format.VerticalAlignment == "" && format.WrapStrategy == ""
+	}
+	if empty {
+		return nil
+	}
+	return &format
+}
+
+var (
+	numberFormatLocales  = regexp.MustCompile(`\[\$-[0-9A-Fa-f]+\]`)
+	numberFormatLiterals = regexp.MustCompile(`"[^"]*"|\\.|_.|\*.`)
+	numberFormatSections = regexp.MustCompile(`\[[^\]]*\]`)
+	numberFormatCurrency = regexp.MustCompile(`[$€£¥]`)
+)
+
+// numberFormatType guesses the type of the number format from the pattern.
+func numberFormatType(pattern string) string {
+	p := numberFormatLocales.ReplaceAllString(pattern, "")
+	currency := numberFormatCurrency.MatchString(p)
+	p = numberFormatLiterals.ReplaceAllString(p, "")
+	p = numberFormatSections.ReplaceAllStringFunc(strings.ToLower(p), func(s string) string {
+		// keep elapsed time like [h]
+		if strings.Trim(s, "[]hms") == "" {
+			return strings.Trim(s, "[]")
+		}
+		return ""
+	})
+	date := strings.ContainsAny(p, "yd")
+	time := strings.ContainsAny(p, "hs")
+	switch {
+	case date && time:
+		return NumberFormatDateTime
+	case date:
+		return NumberFormatDate
+	case time:
+		return NumberFormatTime
+	case p == "@":
+		return NumberFormatText
+	case currency:
+		return NumberFormatCurrency
+	case strings.Contains(p, "%"):
+		return NumberFormatPercent
+	case strings.Contains(p, "e+") || strings.Contains(p, "e-"):
+		return NumberFormatScientific
+	}
+	return NumberFormatNumber
+}
@@ -0,0 +1,257 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXLSXRoundTrip(t *testing.T) {
+	assert := assert.New(t)
+	spreadsheet := Spreadsheet{Sheets: []Sheet{
+		{Properties: SheetProperties{Title: "Hidden", Index: 1, Hidden: true}},
+		{Properties: SheetProperties{Title: "Data", Index: 0, GridProperties: GridProperties{FrozenRowCount: 1, FrozenColumnCount: 1}}},
+	}}
+	for i := range spreadsheet.Sheets {
+		spreadsheet.Sheets[i].Spreadsheet = &spreadsheet
+	}
+	sheet := &spreadsheet.Sheets[1]
+	sheet.Rows, sheet.Columns = newCells(3, 3)
+	sheet.Update(0, 0, "name <&>")
+	sheet.Update(0, 1, "12.5")
+	sheet.Update(0, 2, "TRUE")
+	sheet.Update(1, 0, "=A1&\"!\"")
+	sheet.Rows[1][0].effectiveValue = ExtendedValue{StringValue: "name <&>!", kind: ValueKindString}
+	sheet.Update(1, 2, "=1/0")
+	sheet.Rows[1][2].effectiveValue = ExtendedValue{ErrorValue: ErrorValue{Type: ErrorTypeDivideByZero}, kind: ValueKindError}
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, sheet.UpdateTime(1, 1, tm, NumberFormatDate))
+	format := &CellFormat{
+		BackgroundColor:     &Color{Green: 1},
+		HorizontalAlignment: "CENTER",
+		VerticalAlignment:   "MIDDLE",
+		WrapStrategy:        "WRAP",
+		TextFormat:          &TextFormat{Bold: true, FontSize: 12, FontFamily: "Arial", ForegroundColor: &Color{Red: 1}},
+	}
+	sheet.Rows[0][0].format = format
+	sheet.Rows[0][1].format = &CellFormat{NumberFormat: &NumberFormat{Type: NumberFormatCurrency, Pattern: `"$"#,##0.00`}}
+	sheet.Update(3, 2, "end")
+	sheet.Rows[3][1].format = format
+	sheet.Rows[3][3].format = format
+	sheet.columnMetadata = setDimensionProperties(nil, 1, &DimensionProperties{PixelSize: 150})
+	sheet.Merges = []GridRange{{StartRowIndex: 2, EndRowIndex: 4, StartColumnIndex: 0, EndColumnIndex: 2}}
+
+	var buf bytes.Buffer
+	require.NoError(t, spreadsheet.WriteXLSX(&buf))
+	imported, err := ReadXLSX(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Equal(t, 2, len(imported.Sheets))
+
+	hidden := imported.Sheets[1]
+	assert.Equal("Hidden", hidden.Properties.Title)
+	assert.True(hidden.Properties.Hidden)
+
+	data := &imported.Sheets[0]
+	assert.Equal(uint(1), data.Properties.ID)
+	assert.Equal("Data", data.Properties.Title)
+	assert.Equal(uint(1), data.Properties.GridProperties.FrozenRowCount)
+	assert.Equal(uint(1), data.Properties.GridProperties.FrozenColumnCount)
+	assert.Equal(uint(1000), data.Properties.GridProperties.RowCount)
+	assert.Equal(&imported, data.Spreadsheet)
+
+	s, ok := data.Rows[0][0].String()
+	assert.True(ok)
+	assert.Equal("name <&>", s)
+	assert.Equal(format, data.Rows[0][0].UserEnteredFormat())
+	f, ok := data.Rows[0][1].Float()
+	assert.True(ok)
+	assert.Equal(12.5, f)
+	assert.Equal(&NumberFormat{Type: NumberFormatCurrency, Pattern: `"$"#,##0.00`}, data.Rows[0][1].UserEnteredFormat().NumberFormat)
+	b, ok := data.Rows[0][2].Bool()
+	assert.True(ok)
+	assert.True(b)
+
+	formula, ok := data.Rows[1][0].Formula()
+	assert.True(ok)
+	assert.Equal("=A1&\"!\"", formula)
+	assert.Equal("name <&>!", data.Rows[1][0].Value)
+	got, err := data.Rows[1][1].Time()
+	assert.NoError(err)
+	assert.Equal(tm, got)
+	assert.Equal(&NumberFormat{Type: NumberFormatDate}, data.Rows[1][1].UserEnteredFormat().NumberFormat)
+	assert.Equal(ErrorTypeDivideByZero, data.Rows[1][2].Err().(ErrorValue).Type)
+	assert.Equal(format, data.Rows[3][1].UserEnteredFormat())
+	assert.Equal(ValueKindNone, data.Rows[3][1].RawValue().Kind())
+	// the styled cell past the last cell with a value is dropped
+	assert.Equal(3, len(data.Rows[3]))
+	assert.Equal(uint(26), data.Properties.GridProperties.ColumnCount)
+
+	assert.Equal(uint(150), data.ColumnMetadata(1).PixelSize)
+	assert.Equal([]GridRange{{SheetID: 1, StartRowIndex: 2, EndRowIndex: 4, StartColumnIndex: 0, EndColumnIndex: 2}}, data.Merges)
+}
+
+func TestXLSXSheetNames(t *testing.T) {
+	long := strings.Repeat("x", 40)
+	spreadsheet := Spreadsheet{Sheets: []Sheet{
+		{Properties: SheetProperties{Title: "a/b", Index: 0}},
+		{Properties: SheetProperties{Title: "a?b", Index: 1}},
+		{Properties: SheetProperties{Title: "A_B", Index: 2}},
+		{Properties: SheetProperties{Title: long, Index: 3}},
+		{Properties: SheetProperties{Title: long + "y", Index: 4}},
+	}}
+	var buf bytes.Buffer
+	require.NoError(t, spreadsheet.WriteXLSX(&buf))
+	imported, err := ReadXLSX(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	titles := []string{}
+	for _, sheet := range imported.Sheets {
+		titles = append(titles, sheet.Properties.Title)
+	}
+	assert.Equal(t, []string{"a_b", "a_b (2)", "A_B (3)", long[:31], long[:27] + " (2)"}, titles)
+}
+
+func TestReadXLSX(t *testing.T) {
+	assert := assert.New(t)
+	files := map[string]string{
+		"_rels/.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+			<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+		</Relationships>`,
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+			<sheets><sheet name="Report" sheetId="3" r:id="rId7"/></sheets>
+		</workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+			<Relationship Id="rId7" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="/xl/worksheets/report.xml"/>
+			<Relationship Id="rId8" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/sharedStrings" Target="sharedStrings.xml"/>
+			<Relationship Id="rId9" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+		</Relationships>`,
+		"xl/sharedStrings.xml": `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+			<si><t>plain</t></si>
+			<si><r><t>rich </t></r><r><rPr><b/></rPr><t>text</t></r></si>
+		</sst>`,
+		"xl/styles.xml": `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+			<numFmts count="1"><numFmt numFmtId="170" formatCode="yyyy/mm/dd hh:mm"/></numFmts>
+			<fonts count="2"><font><sz val="11"/></font><font><b val="0"/><i/><sz val="10.5"/><color theme="1"/><name val="Meiryo"/></font></fonts>
+			<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>
+			<cellXfs count="3"><xf numFmtId="0" fontId="0" fillId="0"/><xf numFmtId="170" fontId="1" fillId="0"/><xf numFmtId="0" fontId="0" fillId="0"/></cellXfs>
+		</styleSheet>`,
+		"xl/worksheets/report.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+			<sheetViews><sheetView workbookViewId="0"><pane ySplit="2" topLeftCell="A3" state="frozen"/></sheetView></sheetViews>
+			<cols><col min="2" max="3" width="20" customWidth="1"/><col min="4" max="16384" width="9" hidden="1"/></cols>
+			<sheetData>
+				<row r="2"><c r="B2" t="s"><v>0</v></c><c r="C2" t="s" s="2"><v>1</v></c></row>
+				<row r="3"><c r="A3" s="1"><v>43831.5</v></c><c r="B3"><f t="shared" ref="B3:B4" si="0">A3*2</f><v>87663</v></c></row>
+				<row r="4"><c r="B4"><f t="shared" si="0"/><v>4</v></c><c r="AA4" t="e"><v>#N/A</v></c></row>
+			</sheetData>
+			<mergeCells count="1"><mergeCell ref="B2:C2"/></mergeCells>
+		</worksheet>`,
+	}
+	spreadsheet, err := readTestXLSX(files)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(spreadsheet.Sheets))
+	sheet := spreadsheet.Sheets[0]
+	assert.Equal("Report", sheet.Properties.Title)
+	assert.Equal(uint(2), sheet.Properties.GridProperties.FrozenRowCount)
+	assert.Equal(uint(0), sheet.Properties.GridProperties.FrozenColumnCount)
+	assert.Equal(uint(27), sheet.Properties.GridProperties.ColumnCount)
+
+	assert.Equal("plain", sheet.Rows[1][1].Value)
+	assert.Equal("rich text", sheet.Rows[1][2].Value)
+	assert.Nil(sheet.Rows[1][2].UserEnteredFormat())
+	format := sheet.Rows[2][0].UserEnteredFormat()
+	assert.Equal(&NumberFormat{Type: NumberFormatDateTime, Pattern: "yyyy/mm/dd hh:mm"}, format.NumberFormat)
+	assert.Equal(&TextFormat{Italic: true, FontSize: 11, FontFamily: "Meiryo"}, format.TextFormat)
+
+	formula, ok := sheet.Rows[2][1].Formula()
+	assert.True(ok)
+	assert.Equal("=A3*2", formula)
+	formula, ok = sheet.Rows[3][1].Formula()
+	assert.True(ok)
+	assert.Equal("=A4*2", formula)
+	n, ok := sheet.Rows[3][1].Int()
+	assert.True(ok)
+	assert.Equal(4, n)
+	assert.Equal(ErrorTypeNA, sheet.Rows[3][26].Err().(ErrorValue).Type)
+
+	assert.Equal(uint(140), sheet.ColumnMetadata(2).PixelSize)
+	assert.True(sheet.ColumnMetadata(26).HiddenByUser)
+	assert.Equal(27, len(sheet.columnMetadata))
+	assert.Equal([]GridRange{{SheetID: 1, StartRowIndex: 1, EndRowIndex: 2, StartColumnIndex: 1, EndColumnIndex: 3}}, sheet.Merges)
+
+	// styled cells to the end of the sheet are not allocated
+	files["xl/worksheets/report.xml"] = `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+		<row r="1"><c r="A1"><v>1</v></c><c r="XFD1" s="1"/></row>
+		<row r="1048576"><c r="A1048576" s="1"/></row>
+	</sheetData></worksheet>`
+	spreadsheet, err = readTestXLSX(files)
+	require.NoError(t, err)
+	sheet = spreadsheet.Sheets[0]
+	assert.Equal(1, len(sheet.Rows))
+	assert.Equal(1, len(sheet.Rows[0]))
+	assert.Equal(uint(1000), sheet.Properties.GridProperties.RowCount)
+
+	files["xl/worksheets/report.xml"] = `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+		<row r="1048576"><c r="XFD1048576"><v>1</v></c></row>
+	</sheetData></worksheet>`
+	_, err = readTestXLSX(files)
+	assert.EqualError(err, "workbook has more than 10000000 cells")
+
+	// references past the last cell of a sheet must not wrap around
+	files["xl/worksheets/report.xml"] = `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+		<row r="1"><c r="GKGWBYLWRXTLPP1"><v>1</v></c></row>
+	</sheetData></worksheet>`
+	_, err = readTestXLSX(files)
+	assert.Error(err)
+	files["xl/worksheets/report.xml"] = `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+		<row r="1048577"><c><v>1</v></c></row>
+	</sheetData></worksheet>`
+	_, err = readTestXLSX(files)
+	assert.EqualError(err, "row 1048577 is out of range")
+
+	files["xl/worksheets/report.xml"] = `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+		<row r="1"><c r="A1"><f t="shared" si="0"/><v>1</v></c></row>
+	</sheetData></worksheet>`
+	_, err = readTestXLSX(files)
+	assert.EqualError(err, `shared formula "0" of cell A1 is not defined`)
+}
+
+func TestShiftFormula(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("B3+$A3*C$1+$D$4", shiftFormula("A1+$A1*B$1+$D$4", 2, 1))
+	assert.Equal("SUM(B2:C3)+SUM(C:D)+SUM(3:$4)", shiftFormula("SUM(A1:B2)+SUM(B:C)+SUM(2:$4)", 1, 1))
+	assert.Equal(`'A1 B'!B2&"A1"&Sheet1!B2`, shiftFormula(`'A1 B'!A1&"A1"&Sheet1!A1`, 1, 1))
+	assert.Equal("LOG10(B2)+ATAN2(B2,1)+A1B", shiftFormula("LOG10(A1)+ATAN2(A1,1)+A1B", 1, 1))
+	assert.Equal("#REF!+A$2", shiftFormula("A1+B$2", -1, -1))
+	assert.Equal("A1", shiftFormula("A1", 0, 0))
+}
+
+func readTestXLSX(files map[string]string) (Spreadsheet, error) {
+	var buf bytes.Buffer
+	z := zip.NewWriter(&buf)
+	for name, data := range files {
+		if err := writeZipFile(z, name, []byte(data)); err != nil {
+			return Spreadsheet{}, err
+		}
+	}
+	if err := z.Close(); err != nil {
+		return Spreadsheet{}, err
+	}
+	return ReadXLSX(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+}
+
+func TestNumberFormatType(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal(NumberFormatDate, numberFormatType("yyyy-mm-dd"))
+	assert.Equal(NumberFormatDateTime, numberFormatType("[$-409]m/d/yy h:mm AM/PM;@"))
+	assert.Equal(NumberFormatTime, numberFormatType("[h]:mm"))
+	assert.Equal(NumberFormatNumber, numberFormatType(`#,##0.00" days"`))
+	assert.Equal(NumberFormatNumber, numberFormatType("[Red]#,##0;[Blue]-#,##0"))
+	assert.Equal(NumberFormatCurrency, numberFormatType("[$€-407]#,##0.00"))
+	assert.Equal(NumberFormatPercent, numberFormatType("0.0%"))
+	assert.Equal(NumberFormatScientific, numberFormatType("0.0E+00"))
+	assert.Equal(NumberFormatText, numberFormatType("@"))
+}